@@ -0,0 +1,9 @@
+package jx3openapigeneration
+
+import "embed"
+
+// Assets holds the default language configs, packaging templates and the openapi-generator-cli config schema so that
+// the CLI can run outside the Docker image
+//
+//go:embed configs all:templates openapitoolschema.json
+var Assets embed.FS
@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/pkg/errors"
+	jx3openapigeneration "github.com/spring-financial-group/jx3-openapi-generation"
+)
+
+const (
+	configsDir       = "configs"
+	templatesDir     = "templates"
+	configSchemaFile = "openapitoolschema.json"
+)
+
+// DefaultConfigs returns the openapi-generator configs embedded in the binary
+func DefaultConfigs() fs.FS {
+	return mustSub(configsDir)
+}
+
+// DefaultTemplates returns the packaging templates embedded in the binary
+func DefaultTemplates() fs.FS {
+	return mustSub(templatesDir)
+}
+
+// ConfigSchema returns the JSON schema for the openapi-generator-cli config
+func ConfigSchema() []byte {
+	data, err := fs.ReadFile(jx3openapigeneration.Assets, configSchemaFile)
+	if err != nil {
+		// The schema is embedded at compile time so this can only happen if the embed directive is changed
+		panic(err)
+	}
+	return data
+}
+
+// Configs returns the openapi-generator configs from the given directory, falling back to the embedded defaults if
+// no directory is given
+func Configs(dir string) (fs.FS, error) {
+	if dir == "" {
+		return DefaultConfigs(), nil
+	}
+	return dirFS(dir)
+}
+
+// Templates returns the packaging templates from the given directory, falling back to the embedded defaults if no
+// directory is given
+func Templates(dir string) (fs.FS, error) {
+	if dir == "" {
+		return DefaultTemplates(), nil
+	}
+	return dirFS(dir)
+}
+
+func dirFS(dir string) (fs.FS, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat %s", dir)
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%s is not a directory", dir)
+	}
+	return os.DirFS(dir), nil
+}
+
+func mustSub(dir string) fs.FS {
+	sub, err := fs.Sub(jx3openapigeneration.Assets, dir)
+	if err != nil {
+		// The directories are embedded at compile time so this can only happen if the embed directive is changed
+		panic(err)
+	}
+	return sub
+}
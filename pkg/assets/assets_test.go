@@ -0,0 +1,76 @@
+//go:build unit
+
+package assets_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfigs(t *testing.T) {
+	languages := []string{domain.Rust, domain.CSharp, domain.Java, domain.Angular, domain.Python, domain.Javascript, domain.Typescript, domain.Go}
+
+	configs := assets.DefaultConfigs()
+	for _, language := range languages {
+		t.Run(language, func(t *testing.T) {
+			_, err := fs.Stat(configs, language+"-openapitools.json")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	templates := assets.DefaultTemplates()
+
+	// Dotfiles are excluded from embedded directories by default so make sure they have been picked up
+	for _, path := range []string{"angular/.npmrc", "typescript/.npmrc", "javascript/.npmrc", "java/build.gradle", "csharp/nuget.config"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := fs.Stat(templates, path)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	t.Run("Defaults to embedded configs", func(t *testing.T) {
+		configs, err := assets.Configs("")
+		require.NoError(t, err)
+
+		_, err = fs.Stat(configs, "java-openapitools.json")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Reads from directory", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "java-openapitools.json"), []byte(`{"custom": true}`), 0600)
+		require.NoError(t, err)
+
+		configs, err := assets.Configs(dir)
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(configs, "java-openapitools.json")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"custom": true}`, string(data))
+	})
+
+	t.Run("Errors when directory does not exist", func(t *testing.T) {
+		_, err := assets.Configs(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Errors when path is a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		err := os.WriteFile(path, nil, 0600)
+		require.NoError(t, err)
+
+		_, err = assets.Templates(path)
+		assert.Error(t, err)
+	})
+}
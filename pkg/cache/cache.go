@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+)
+
+const publishedMarker = "published"
+
+// Cache is a local directory of generation results keyed by language and cache key. A marker records that the
+// package for a key has been published, and the package output for each version is kept so that it can be reused,
+// e.g. when a push failed or the previous run skipped pushing.
+type Cache struct {
+	Dir    string
+	FileIO domain.FileIO
+}
+
+func New(dir string) *Cache {
+	return &Cache{
+		Dir:    dir,
+		FileIO: file.NewFileIO(),
+	}
+}
+
+// IsPublished returns whether a package with the given key has been published
+func (c *Cache) IsPublished(language, key string) (bool, error) {
+	return c.FileIO.Exists(filepath.Join(c.entryDir(language, key), publishedMarker))
+}
+
+// MarkPublished records that the package with the given key has been published
+func (c *Cache) MarkPublished(language, key, version string) error {
+	entryDir, err := c.FileIO.MkdirAll(c.entryDir(language, key), 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create cache entry")
+	}
+	return c.FileIO.Write(filepath.Join(entryDir, publishedMarker), []byte(version), 0600)
+}
+
+// Output returns the cached package output for the given key and version, if there is one
+func (c *Cache) Output(language, key, version string) (string, bool, error) {
+	outputDir := c.outputDir(language, key, version)
+	exists, err := c.FileIO.Exists(outputDir)
+	if err != nil || !exists {
+		return "", false, err
+	}
+	return outputDir, true, nil
+}
+
+// Store copies the package output into the cache, replacing any existing output for the key and version
+func (c *Cache) Store(language, key, version, packageDir string) error {
+	outputDir := c.outputDir(language, key, version)
+	err := c.FileIO.Remove(outputDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove existing cache output")
+	}
+	_, err = c.FileIO.MkdirAll(filepath.Dir(outputDir), 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create cache entry")
+	}
+
+	// Copy to a temporary directory first so that a failed copy isn't mistaken for cached output
+	tmpDir := outputDir + ".tmp"
+	defer c.FileIO.DeferRemove(tmpDir)
+	err = c.FileIO.CopyDir(packageDir, tmpDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to copy package output to cache")
+	}
+	return c.FileIO.Move(tmpDir, outputDir)
+}
+
+func (c *Cache) entryDir(language, key string) string {
+	return filepath.Join(c.Dir, language, key)
+}
+
+func (c *Cache) outputDir(language, key, version string) string {
+	return filepath.Join(c.entryDir(language, key), "output", version)
+}
@@ -0,0 +1,163 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cache"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInputs(t *testing.T, version string) cache.KeyInputs {
+	cfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), domain.Java, nil)
+	require.NoError(t, err)
+	cfg.GeneratorCLI.Generators[domain.Java].AdditionalProperties["packageVersion"] = version
+	cfg.GeneratorCLI.Generators[domain.Java].Output = filepath.Join(t.TempDir(), "java")
+
+	return cache.KeyInputs{
+		Language:    domain.Java,
+		Spec:        []byte(`{"openapi": "3.0.3"}`),
+		Config:      cfg,
+		Version:     version,
+		ToolVersion: "1.0.0",
+		Templates:   fstest.MapFS{"java/build.gradle": {Data: []byte("version = '{{ .Version }}'")}},
+	}
+}
+
+func TestKey(t *testing.T) {
+	baseKey, err := cache.Key(newInputs(t, "1.0.0"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		modify      func(inputs *cache.KeyInputs)
+		expectEqual bool
+	}{
+		{
+			name:        "Same inputs with a new version and output",
+			modify:      func(inputs *cache.KeyInputs) {},
+			expectEqual: true,
+		},
+		{
+			name: "Spec changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Spec = []byte(`{"openapi": "3.0.4"}`)
+			},
+		},
+		{
+			name: "Config changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Config.GeneratorCLI.Generators[domain.Java].AdditionalProperties["library"] = "native"
+			},
+		},
+		{
+			name: "Generator version changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Config.GeneratorCLI.Version = "7.16.0"
+			},
+		},
+		{
+			name: "Template changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Templates = fstest.MapFS{"java/build.gradle": {Data: []byte("version = '{{ .Version }}-SNAPSHOT'")}}
+			},
+		},
+		{
+			name: "Tool version changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.ToolVersion = "1.1.0"
+			},
+		},
+		{
+			name: "Options changed",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Options = []string{"host=example.com"}
+			},
+		},
+		{
+			name: "Generated without openapi-generator config or templates",
+			modify: func(inputs *cache.KeyInputs) {
+				inputs.Config = nil
+				inputs.Templates = nil
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := newInputs(t, "1.1.0")
+			tt.modify(&inputs)
+
+			key, err := cache.Key(inputs)
+			require.NoError(t, err)
+			if tt.expectEqual {
+				assert.Equal(t, baseKey, key)
+			} else {
+				assert.NotEqual(t, baseKey, key)
+			}
+		})
+	}
+}
+
+func TestKey_WithoutConfig(t *testing.T) {
+	newGoInputs := func(codegenConfig string) cache.KeyInputs {
+		return cache.KeyInputs{
+			Language:    domain.Go,
+			Spec:        []byte(`{"openapi": "3.0.3"}`),
+			Version:     "1.0.0",
+			ToolVersion: "1.0.0",
+			Options:     []string{"server=none", "codegen-config=" + codegenConfig},
+		}
+	}
+
+	key, err := cache.Key(newGoInputs("output-options:\n  skip-prune: true\n"))
+	require.NoError(t, err)
+	sameKey, err := cache.Key(newGoInputs("output-options:\n  skip-prune: true\n"))
+	require.NoError(t, err)
+	changedKey, err := cache.Key(newGoInputs("output-options:\n  skip-prune: false\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, key, sameKey)
+	assert.NotEqual(t, key, changedKey)
+}
+
+func TestCache(t *testing.T) {
+	c := cache.New(t.TempDir())
+
+	packageDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(packageDir, "lib", "client.jar"), []byte("jar"), 0600))
+
+	_, ok, err := c.Output(domain.Java, "key", "1.0.0")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Store(domain.Java, "key", "1.0.0", packageDir))
+	outputDir, ok, err := c.Output(domain.Java, "key", "1.0.0")
+	require.NoError(t, err)
+	require.True(t, ok)
+	data, err := os.ReadFile(filepath.Join(outputDir, "lib", "client.jar"))
+	require.NoError(t, err)
+	assert.Equal(t, "jar", string(data))
+
+	_, ok, err = c.Output(domain.Java, "key", "1.1.0")
+	require.NoError(t, err)
+	assert.False(t, ok, "output is only reused for the same version")
+
+	published, err := c.IsPublished(domain.Java, "key")
+	require.NoError(t, err)
+	assert.False(t, published)
+
+	require.NoError(t, c.MarkPublished(domain.Java, "key", "1.0.0"))
+	published, err = c.IsPublished(domain.Java, "key")
+	require.NoError(t, err)
+	assert.True(t, published)
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/pkg/errors"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+)
+
+// versionPlaceholder replaces the package version in the config so that the same inputs published under a new
+// version have the same key
+const versionPlaceholder = "{{version}}"
+
+// KeyInputs are everything that determines the output of generating a package for a language
+type KeyInputs struct {
+	Language string
+	// Spec is the content of the OpenAPI specification
+	Spec []byte
+	// Config is the effective openapi-generator config for the language, nil if it isn't generated with openapi-generator
+	Config *openapitools.Config
+	// Version is the package version, which is replaced by a placeholder wherever it appears in the config
+	Version string
+	// ToolVersion is the version of this CLI, as the generators' own steps change between versions
+	ToolVersion string
+	// Templates are the packaging templates, nil if the language doesn't use them
+	Templates fs.FS
+	// Options are any other options passed to the generator, e.g. server variables
+	Options []string
+}
+
+// Key returns the content-addressed cache key for the inputs
+func Key(inputs KeyInputs) (string, error) {
+	h := sha256.New()
+	writeField(h, "language", []byte(inputs.Language))
+	writeField(h, "tool", []byte(inputs.ToolVersion))
+	writeField(h, "spec", inputs.Spec)
+
+	if inputs.Config != nil {
+		cfg, err := normaliseConfig(inputs.Config, inputs.Version)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to normalise config")
+		}
+		writeField(h, "config", cfg)
+	}
+
+	for _, option := range inputs.Options {
+		writeField(h, "option", []byte(option))
+	}
+
+	if inputs.Templates != nil {
+		err := fs.WalkDir(inputs.Templates, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(inputs.Templates, path)
+			if err != nil {
+				return err
+			}
+			writeField(h, "template:"+path, data)
+			return nil
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to hash templates")
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeField writes a length prefixed field so that adjacent fields can't be confused with each other
+func writeField(w io.Writer, name string, data []byte) {
+	_, _ = fmt.Fprintf(w, "%s:%d:", name, len(data))
+	_, _ = w.Write(data)
+}
+
+// normaliseConfig returns the config as JSON with the generator outputs, which are temporary directories, removed and
+// the package version replaced by a placeholder. The generator-cli version is kept as it determines the output.
+func normaliseConfig(cfg *openapitools.Config, version string) ([]byte, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var normalised openapitools.Config
+	err = json.Unmarshal(data, &normalised)
+	if err != nil {
+		return nil, err
+	}
+	for _, generator := range normalised.GeneratorCLI.Generators {
+		generator.Output = ""
+	}
+
+	data, err = json.Marshal(normalised)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	// encoding/json sorts map keys so the result is deterministic
+	return json.Marshal(replaceVersion(raw, version))
+}
+
+func replaceVersion(val any, version string) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = replaceVersion(child, version)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = replaceVersion(child, version)
+		}
+		return v
+	case string:
+		if version != "" && v == version {
+			return versionPlaceholder
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/generate"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/rootcmd"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/helper"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/templates"
+)
+
+var (
+	configLong = templates.LongDesc(`
+		Inspects the openapi-generator configs used to generate packages.
+`)
+
+	configExample = templates.Examples(`
+		%s config show java
+	`)
+)
+
+// NewCmdConfig creates a command object for the "config" action, which inspects the generator configs
+func NewCmdConfig() *cobra.Command {
+	o := &generate.Options{
+		FileIO: file.NewFileIO(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Inspects the openapi-generator configs",
+		Long:    configLong,
+		Example: fmt.Sprintf(configExample, rootcmd.BinaryName),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			helper.CheckErr(err)
+		},
+		SuggestFor: []string{"cfg", "conf"},
+		// Only read what resolving the configs needs, so that they can be inspected outside of a pipeline
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.InitialiseConfig()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&o.ConfigsDir, "configs-dir", "", "directory containing the <language>-openapitools.json configs, overrides the embedded defaults")
+	cmd.PersistentFlags().StringVar(&o.TemplatesDir, "templates-dir", "", "directory containing the packaging templates for each language, overrides the embedded defaults")
+
+	cmd.AddCommand(NewCmdConfigShow(o))
+	return cmd
+}
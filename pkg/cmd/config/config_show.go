@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/generate"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/rootcmd"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/helper"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/templates"
+)
+
+// ShowOptions contains the options for the show command
+type ShowOptions struct {
+	*generate.PackageOptions
+
+	Diff bool
+	Out  io.Writer
+}
+
+var (
+	showLong = templates.LongDesc(`
+		Prints the resolved openapitools.json that would be passed to openapi-generator for a language, after the
+		environment, language specific variables and per-service overrides have been applied.
+`)
+
+	showExample = templates.Examples(`
+		# Print the resolved java config
+		%[1]s config show java
+
+		# Diff the resolved java config against the default shipped with the binary
+		%[1]s config show java --diff
+	`)
+)
+
+// NewCmdConfigShow creates a command object for the "config show" action
+func NewCmdConfigShow(opts *generate.Options) *cobra.Command {
+	o := &ShowOptions{
+		PackageOptions: &generate.PackageOptions{
+			Options:   opts,
+			CmdRunner: commandrunner.NewCommandRunner(),
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show <language>",
+		Short:   "Prints the resolved openapi-generator config for a language",
+		Long:    showLong,
+		Example: fmt.Sprintf(showExample, rootcmd.BinaryName),
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return errors.Wrap(o.InitialiseGenerators(), "failed to initialise generators")
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			o.Cmd = cmd
+			o.Args = args
+			o.Out = cmd.OutOrStdout()
+			err := o.Run(args[0])
+			helper.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Diff, "diff", false, "show a diff against the default config shipped with the binary")
+	return cmd
+}
+
+// Run implements this command
+func (o *ShowOptions) Run(language string) error {
+	generator, err := o.Generator(language)
+	if err != nil {
+		return err
+	}
+	resolver, ok := generator.(packagegenerator.ConfigResolver)
+	user, isUser := generator.(packagegenerator.OpenAPIGeneratorUser)
+	if !ok || !isUser || !user.UsesOpenAPIGenerator() {
+		return errors.Errorf("%s packages are not generated with openapi-generator", language)
+	}
+	cfg := resolver.ResolveConfig()
+
+	if o.ServerVariables != "" {
+		log.Info().Msgf("%sServer variables are passed on the command line: %s%s", utils.Cyan, o.ServerVariables, utils.Reset)
+	}
+
+	if !o.Diff {
+		data, err := utils.MarshalJSON(cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal config")
+		}
+		_, err = o.Out.Write(data)
+		return err
+	}
+
+	defaultCfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), language, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get default config for %s", language)
+	}
+	diff, err := DiffConfigs(language, defaultCfg, cfg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(o.Out, diff)
+	return err
+}
+
+// DiffConfigs returns a unified diff of the JSON representations of two configs
+func DiffConfigs(language string, from, to *openapitools.Config) (string, error) {
+	fromData, err := utils.MarshalJSON(from)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal default config")
+	}
+	toData, err := utils.MarshalJSON(to)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal resolved config")
+	}
+
+	fileName := language + "-" + openapitools.OpenAPIConfigFileName
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(fromData)),
+		B:        difflib.SplitLines(string(toData)),
+		FromFile: "default/" + fileName,
+		ToFile:   "resolved/" + fileName,
+		Context:  3,
+	})
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/config"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/generate"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	defaultCfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), domain.Java, nil)
+	require.NoError(t, err)
+
+	t.Run("Unchanged config has no diff", func(t *testing.T) {
+		resolved, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), domain.Java, nil)
+		require.NoError(t, err)
+
+		diff, err := config.DiffConfigs(domain.Java, defaultCfg, resolved)
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("Changed config shows the changed lines", func(t *testing.T) {
+		resolved, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), domain.Java, nil)
+		require.NoError(t, err)
+		resolved.GeneratorCLI.Generators[domain.Java].AdditionalProperties["packageVersion"] = "1.2.3"
+
+		diff, err := config.DiffConfigs(domain.Java, defaultCfg, resolved)
+		require.NoError(t, err)
+		assert.Contains(t, diff, "--- default/java-openapitools.json")
+		assert.Contains(t, diff, "+++ resolved/java-openapitools.json")
+		assert.Contains(t, diff, `+          "packageVersion": "1.2.3",`)
+	})
+}
+
+func TestShowOptions_Run(t *testing.T) {
+	// Printing a config shouldn't need any of the variables that only name and publish packages
+	for _, key := range []string{"VERSION", "REPO_OWNER", "REPO_NAME", "SwaggerServiceName", "SpecPath", "GIT_USER", "GIT_TOKEN", "ConfigOverridesPath", "CONFIGS_DIR", "TEMPLATES_DIR"} {
+		t.Setenv(key, "")
+	}
+
+	testCases := []struct {
+		name             string
+		language         string
+		expectedContains []string
+		expectedErr      string
+	}{
+		{
+			name:             "Resolves the config with placeholders",
+			language:         domain.Java,
+			expectedContains: []string{`"packageVersion": "0.0.0"`, `"gitRepoId": "service"`},
+		},
+		{
+			name:        "Go isn't generated with openapi-generator",
+			language:    domain.Go,
+			expectedErr: "go packages are not generated with openapi-generator",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &generate.Options{FileIO: file.NewFileIO()}
+			require.NoError(t, opts.InitialiseConfig())
+
+			out := &bytes.Buffer{}
+			o := &config.ShowOptions{PackageOptions: &generate.PackageOptions{Options: opts}, Out: out}
+			require.NoError(t, o.InitialiseGenerators())
+
+			err := o.Run(tt.language)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
@@ -2,14 +2,19 @@ package generate
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapigenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/rootcmd"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
 	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/helper"
@@ -31,9 +36,20 @@ type Options struct {
 	GitToken           string
 	SkipPush           bool
 	ServerVariables    string
+	ConfigsDir         string
+	TemplatesDir       string
+	OverridesPath      string
+	GeneratorJar       string
+	GeneratorJarSHA256 string
+	CacheDir           string
 
 	FileIO      domain.FileIO
 	PackageName string
+	Configs     fs.FS
+	Templates   fs.FS
+	Overrides   openapitools.Overrides
+
+	OpenAPIGenerator domain.OpenAPIGenerator
 }
 
 // Constants for environment variables required by the command
@@ -48,6 +64,20 @@ const (
 	gitTokenKey           = "GIT_TOKEN"
 	packageNameKey        = "PackageName"
 	skipPushKey           = "SKIP_PUSH"
+	configsDirKey         = "CONFIGS_DIR"
+	templatesDirKey       = "TEMPLATES_DIR"
+	overridesPathKey      = "ConfigOverridesPath"
+	generatorJarKey       = "OPENAPI_GENERATOR_JAR"
+	generatorJarSHA256Key = "OPENAPI_GENERATOR_JAR_SHA256"
+	cacheDirKey           = "GENERATION_CACHE_DIR"
+)
+
+// Placeholders for the variables that only name and publish packages, used when inspecting configs without them set
+const (
+	placeholderVersion     = "0.0.0"
+	placeholderRepoOwner   = "owner"
+	placeholderRepoName    = "service"
+	placeholderServiceName = "Service"
 )
 
 const (
@@ -90,10 +120,18 @@ func NewCmdGenerate() *cobra.Command {
 		// Initialize environment variables at execution time, not creation time
 		// Use PersistentPreRunE so it runs before subcommands' PreRunE
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			return o.initialise()
+			return o.Initialise()
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&o.ConfigsDir, "configs-dir", "", fmt.Sprintf("directory containing the <language>-openapitools.json configs, overrides $%s and the embedded defaults", configsDirKey))
+	cmd.PersistentFlags().StringVar(&o.TemplatesDir, "templates-dir", "", fmt.Sprintf("directory containing the packaging templates for each language, overrides $%s and the embedded defaults", templatesDirKey))
+
+	cmd.PersistentFlags().StringVar(&o.GeneratorJar, "generator-jar", "", fmt.Sprintf("path to an openapi-generator-cli jar to run with java instead of npx, overrides $%s", generatorJarKey))
+	cmd.PersistentFlags().StringVar(&o.GeneratorJarSHA256, "generator-jar-sha256", "", fmt.Sprintf("expected sha256 checksum of the openapi-generator-cli jar, overrides $%s", generatorJarSHA256Key))
+
+	cmd.PersistentFlags().StringVar(&o.CacheDir, "cache-dir", "", fmt.Sprintf("directory to cache generated packages in, skipping languages whose inputs haven't changed since they were last published, overrides $%s", cacheDirKey))
+
 	cmd.AddCommand(NewCmdGeneratePackages(o))
 	return cmd
 }
@@ -103,7 +141,8 @@ func (o *Options) Run() error {
 	return o.Cmd.Help()
 }
 
-func (o *Options) initialise() error {
+// Initialise reads the options from the environment and loads the specification, assets and overrides
+func (o *Options) Initialise() error {
 	err := o.getVariablesFromEnvironment()
 	if err != nil {
 		return err
@@ -112,9 +151,64 @@ func (o *Options) initialise() error {
 	if err != nil {
 		return err
 	}
+	err = o.loadAssets()
+	if err != nil {
+		return err
+	}
+	err = o.loadOverrides()
+	if err != nil {
+		return err
+	}
+	err = o.loadOpenAPIGenerator()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
+// InitialiseConfig reads only the options needed to resolve the generator configs and loads the assets and overrides.
+// The variables that only name and publish packages are defaulted if unset, and the specification isn't required to exist.
+func (o *Options) InitialiseConfig() error {
+	var defaulted []string
+	getEnvOrPlaceholder := func(key, placeholder string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		defaulted = append(defaulted, key)
+		return placeholder
+	}
+	o.Version = getEnvOrPlaceholder(versionKey, placeholderVersion)
+	o.RepoOwner = getEnvOrPlaceholder(repoOwnerKey, placeholderRepoOwner)
+	o.RepoName = getEnvOrPlaceholder(repoNameKey, placeholderRepoName)
+	o.SwaggerServiceName = getEnvOrPlaceholder(swaggerServiceNameKey, placeholderServiceName)
+	if len(defaulted) > 0 {
+		log.Info().Msgf("%sUsing placeholders for unset %s%s", utils.Cyan, strings.Join(defaulted, ", "), utils.Reset)
+	}
+	o.SpecPath = os.Getenv(specPathKey)
+	o.getConfigVariablesFromEnvironment()
+
+	err := o.loadAssets()
+	if err != nil {
+		return err
+	}
+	return o.loadOverrides()
+}
+
+// getConfigVariablesFromEnvironment reads the optional variables that change the resolved generator configs
+func (o *Options) getConfigVariablesFromEnvironment() {
+	if o.PackageName = os.Getenv(packageNameKey); o.PackageName == "" {
+		o.PackageName = "Client"
+	}
+	o.ServerVariables = os.Getenv(serverVariables)
+	o.OverridesPath = os.Getenv(overridesPathKey)
+	if o.ConfigsDir == "" {
+		o.ConfigsDir = os.Getenv(configsDirKey)
+	}
+	if o.TemplatesDir == "" {
+		o.TemplatesDir = os.Getenv(templatesDirKey)
+	}
+}
+
 func (o *Options) getVariablesFromEnvironment() error {
 	var missingVariables []string
 	if o.Version = os.Getenv(versionKey); o.Version == "" {
@@ -129,9 +223,6 @@ func (o *Options) getVariablesFromEnvironment() error {
 	if o.SwaggerServiceName = os.Getenv(swaggerServiceNameKey); o.SwaggerServiceName == "" {
 		missingVariables = append(missingVariables, swaggerServiceNameKey)
 	}
-	if o.PackageName = os.Getenv(packageNameKey); o.PackageName == "" {
-		o.PackageName = "Client"
-	}
 	if o.SpecPath = os.Getenv(specPathKey); o.SpecPath == "" {
 		missingVariables = append(missingVariables, specPathKey)
 	}
@@ -141,7 +232,16 @@ func (o *Options) getVariablesFromEnvironment() error {
 	if o.GitToken = os.Getenv(gitTokenKey); o.GitToken == "" {
 		missingVariables = append(missingVariables, gitTokenKey)
 	}
-	o.ServerVariables = os.Getenv(serverVariables)
+	o.getConfigVariablesFromEnvironment()
+	if o.GeneratorJar == "" {
+		o.GeneratorJar = os.Getenv(generatorJarKey)
+	}
+	if o.GeneratorJarSHA256 == "" {
+		o.GeneratorJarSHA256 = os.Getenv(generatorJarSHA256Key)
+	}
+	if o.CacheDir == "" {
+		o.CacheDir = os.Getenv(cacheDirKey)
+	}
 	// Check if SKIP_PUSH is set to "true"
 	if skipPush := os.Getenv(skipPushKey); skipPush == "true" {
 		o.SkipPush = true
@@ -153,7 +253,7 @@ func (o *Options) getVariablesFromEnvironment() error {
 }
 
 func (o *Options) validateSpecificationLocation() error {
-	absPath, err := o.getAbsoluteSpecPath(o.SpecPath)
+	absPath, err := o.getAbsolutePath(o.SpecPath)
 	if err != nil {
 		return errors.Wrap(err, "failed to get absolute path for specification")
 	}
@@ -170,7 +270,64 @@ func (o *Options) validateSpecificationLocation() error {
 	return nil
 }
 
-func (o *Options) getAbsoluteSpecPath(relativePath string) (string, error) {
+// loadAssets resolves the configs and templates used for generation, falling back to those embedded in the binary
+func (o *Options) loadAssets() error {
+	var err error
+	o.Configs, err = assets.Configs(o.ConfigsDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configs")
+	}
+	o.Templates, err = assets.Templates(o.TemplatesDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to load templates")
+	}
+	if o.ConfigsDir != "" {
+		log.Info().Msgf("%sUsing configs from %s%s", utils.Cyan, o.ConfigsDir, utils.Reset)
+	}
+	if o.TemplatesDir != "" {
+		log.Info().Msgf("%sUsing templates from %s%s", utils.Cyan, o.TemplatesDir, utils.Reset)
+	}
+	return nil
+}
+
+// loadOverrides reads the per-service overrides for the generator configs if the service has any
+func (o *Options) loadOverrides() error {
+	if o.OverridesPath == "" {
+		return nil
+	}
+
+	absPath, err := o.getAbsolutePath(o.OverridesPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to get absolute path for config overrides")
+	}
+
+	o.Overrides, err = openapitools.LoadOverrides(absPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to load config overrides")
+	}
+	log.Info().Msgf("%sConfig overrides found at %s%s", utils.Cyan, absPath, utils.Reset)
+	return nil
+}
+
+// loadOpenAPIGenerator verifies the openapi-generator jar if one is configured, otherwise the generators use npx
+func (o *Options) loadOpenAPIGenerator() error {
+	if o.GeneratorJar == "" {
+		return nil
+	}
+
+	absPath, err := o.getAbsolutePath(o.GeneratorJar)
+	if err != nil {
+		return errors.Wrap(err, "failed to get absolute path for generator jar")
+	}
+
+	o.OpenAPIGenerator, err = openapigenerator.NewJarClient(absPath, o.GeneratorJarSHA256)
+	if err != nil {
+		return errors.Wrap(err, "failed to load generator jar")
+	}
+	return nil
+}
+
+func (o *Options) getAbsolutePath(relativePath string) (string, error) {
 	// If the path is already absolute, return it as-is
 	if filepath.IsAbs(relativePath) {
 		return relativePath, nil
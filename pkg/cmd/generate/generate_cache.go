@@ -0,0 +1,144 @@
+package generate
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cache"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/version"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// reusableOutputLanguages are the languages whose packages are pushed from a self-contained directory, so cached
+// output can be pushed again. The others are committed to a clone of another repository and are always regenerated.
+var reusableOutputLanguages = map[string]bool{
+	domain.CSharp:     true,
+	domain.Java:       true,
+	domain.Angular:    true,
+	domain.Javascript: true,
+	domain.Typescript: true,
+}
+
+// initialiseCache computes the cache key for each language if a cache directory is configured
+func (o *PackageOptions) initialiseCache(languages []string) error {
+	if o.CacheDir == "" {
+		return nil
+	}
+	o.cache = cache.New(o.CacheDir)
+	o.cacheKeys = make(map[string]string)
+
+	spec, err := o.FileIO.Read(o.SpecPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read specification")
+	}
+
+	for _, l := range languages {
+		inputs := cache.KeyInputs{
+			Language:    l,
+			Spec:        spec,
+			Version:     o.Version,
+			ToolVersion: version.Version,
+		}
+		// Only packages generated with openapi-generator depend on its config, the server variables passed to it and
+		// the packaging templates. The others are keyed on their own options.
+		if user, ok := o.languageGenerators[l].(packagegenerator.OpenAPIGeneratorUser); !ok || user.UsesOpenAPIGenerator() {
+			resolver, ok := o.languageGenerators[l].(packagegenerator.ConfigResolver)
+			if !ok {
+				continue
+			}
+			inputs.Config = resolver.ResolveConfig()
+			inputs.Templates = o.Templates
+			inputs.Options = []string{o.ServerVariables}
+		}
+		if provider, ok := o.languageGenerators[l].(packagegenerator.OptionsProvider); ok {
+			inputs.Options = append(inputs.Options, provider.GenerationOptions()...)
+		}
+		key, err := cache.Key(inputs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute cache key for %s", l)
+		}
+		o.cacheKeys[l] = key
+	}
+	return nil
+}
+
+// skipUnchanged returns the languages that have changed since their packages were last published
+func (o *PackageOptions) skipUnchanged(languages []string) ([]string, error) {
+	if o.cache == nil {
+		return languages, nil
+	}
+
+	var changed []string
+	for _, l := range languages {
+		key, ok := o.cacheKeys[l]
+		if !ok {
+			changed = append(changed, l)
+			continue
+		}
+		published, err := o.cache.IsPublished(l, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check cache for %s", l)
+		}
+		if published {
+			log.Info().Msgf("%s%s package unchanged, skipping%s", utils.Yellow, l, utils.Reset)
+			continue
+		}
+		changed = append(changed, l)
+	}
+	return changed, nil
+}
+
+// cachedOutput returns the cached package output for the language, if it can be reused
+func (o *PackageOptions) cachedOutput(l string) (string, bool, error) {
+	key, ok := o.cacheKeys[l]
+	if o.cache == nil || !ok || !reusableOutputLanguages[l] {
+		return "", false, nil
+	}
+	return o.cache.Output(l, key, o.Version)
+}
+
+// generatePackage generates the package for the language, reusing the cached output if there is any
+func (o *PackageOptions) generatePackage(l, outputDir string) (string, error) {
+	cachedDir, ok, err := o.cachedOutput(l)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check cache for %s", l)
+	}
+	if ok {
+		log.Info().Msgf("%sReusing cached %s client package%s", utils.Green, l, utils.Reset)
+		packageDir := filepath.Join(outputDir, filepath.Base(cachedDir))
+		err = o.FileIO.CopyDir(cachedDir, packageDir)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to copy cached %s package", l)
+		}
+		return packageDir, nil
+	}
+
+	log.Info().Msgf("%sGenerating %s client package%s", utils.Green, l, utils.Reset)
+	packageDir, err := o.languageGenerators[l].GeneratePackage(outputDir)
+	if err != nil {
+		return "", err
+	}
+	return packageDir, o.storeOutput(l, packageDir)
+}
+
+// storeOutput caches the generated package output if it can be reused
+func (o *PackageOptions) storeOutput(l, packageDir string) error {
+	key, ok := o.cacheKeys[l]
+	if o.cache == nil || !ok || !reusableOutputLanguages[l] {
+		return nil
+	}
+	return errors.Wrapf(o.cache.Store(l, key, o.Version, packageDir), "failed to cache %s package", l)
+}
+
+// markPublished records that the package for the language has been published so that it is skipped until its inputs
+// change
+func (o *PackageOptions) markPublished(l string) error {
+	key, ok := o.cacheKeys[l]
+	if o.cache == nil || !ok {
+		return nil
+	}
+	return errors.Wrapf(o.cache.MarkPublished(l, key, o.Version), "failed to mark %s package as published", l)
+}
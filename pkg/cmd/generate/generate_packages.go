@@ -1,14 +1,18 @@
 package generate
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cache"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapigenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/angular"
@@ -30,8 +34,14 @@ type PackageOptions struct {
 
 	languageGenerators map[string]domain.PackageGenerator
 	CmdRunner          domain.CommandRunner
+	Batch              bool
+
+	cache     *cache.Cache
+	cacheKeys map[string]string
 }
 
+const batchKey = "BATCH_GENERATE"
+
 var (
 	formatLong = templates.LongDesc(`
 		Generates client packages from an OpenAPI/Swagger specification.
@@ -40,6 +50,9 @@ var (
 	formatExample = templates.Examples(`
 		# Generates client packages
 		%s package java
+
+		# Generates client packages with a single openapi-generator invocation
+		%s package java csharp python --batch
 	`)
 )
 
@@ -60,6 +73,9 @@ func NewCmdGeneratePackages(opts *Options) *cobra.Command {
 		// Initialize generators at runtime, not at command creation time
 		// This allows environment variables to be set before initialization
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if os.Getenv(batchKey) == "true" {
+				o.Batch = true
+			}
 			if err := o.InitialiseGenerators(); err != nil {
 				return errors.Wrap(err, "failed to initialise generators")
 			}
@@ -78,6 +94,7 @@ func NewCmdGeneratePackages(opts *Options) *cobra.Command {
 		Aliases:    []string{"pkg", "pkgs", "packages", "package"},
 	}
 
+	cmd.Flags().BoolVar(&o.Batch, "batch", false, fmt.Sprintf("generate all openapi-generator based languages in a single openapi-generator invocation, can also be enabled with $%s=true", batchKey))
 	return cmd
 }
 
@@ -89,26 +106,113 @@ func (o *PackageOptions) Run(languages []string) error {
 	}
 	defer o.FileIO.DeferRemove(tmpDir)
 
+	err = o.initialiseCache(languages)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialise cache")
+	}
+	languages, err = o.skipUnchanged(languages)
+	if err != nil {
+		return err
+	}
+	if len(languages) == 0 {
+		log.Info().Msgf("%sAll packages unchanged, nothing to generate%s", utils.Green, utils.Reset)
+		return nil
+	}
+
+	if o.Batch {
+		return o.runBatch(tmpDir, languages)
+	}
+
 	for _, l := range languages {
-		log.Info().Msgf("%sGenerating %s client package%s", utils.Green, l, utils.Reset)
 		outputDir, err := o.FileIO.MkdirAll(filepath.Join(tmpDir, l), 0700)
 		if err != nil {
 			return errors.Wrapf(err, "failed to make output dir for %s", l)
 		}
 
-		packageDir, err := o.languageGenerators[l].GeneratePackage(outputDir)
+		packageDir, err := o.generatePackage(l, outputDir)
 		if err != nil {
 			return errors.Wrapf(err, "failed to generate %s package", l)
 		}
 
-		if o.SkipPush {
-			log.Info().Msgf("%sSkipping push for %s package (SKIP_PUSH=true)%s", utils.Yellow, l, utils.Reset)
-		} else {
-			log.Info().Msgf("%sPushing %s package%s", utils.Green, l, utils.Reset)
-			err = o.languageGenerators[l].PushPackage(packageDir)
-			if err != nil {
-				return errors.Wrapf(err, "failed to push %s package", l)
+		err = o.pushPackage(l, packageDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msgf("%sSuccessfully generated and pushed packages for languages: %s%s", utils.Green, strings.Join(languages, ", "), utils.Reset)
+	return nil
+}
+
+// runBatch generates the openapi-generator based languages with one openapi-generator invocation per generator
+// version, then runs the per-language build steps. Other languages are generated as normal.
+func (o *PackageOptions) runBatch(tmpDir string, languages []string) error {
+	outputDirs := make(map[string]string)
+	batchGenerators := make(map[string]packagegenerator.BatchGenerator)
+	for _, l := range languages {
+		outputDir, err := o.FileIO.MkdirAll(filepath.Join(tmpDir, l), 0700)
+		if err != nil {
+			return errors.Wrapf(err, "failed to make output dir for %s", l)
+		}
+		outputDirs[l] = outputDir
+
+		_, cached, err := o.cachedOutput(l)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check cache for %s", l)
+		}
+		if generator, ok := o.languageGenerators[l].(packagegenerator.BatchGenerator); ok && !cached {
+			batchGenerators[l] = generator
+		}
+	}
+
+	generatedDirs := make(map[string]string)
+	for _, l := range languages {
+		generator, ok := batchGenerators[l]
+		if !ok {
+			continue
+		}
+		log.Info().Msgf("%sPreparing %s client package%s", utils.Green, l, utils.Reset)
+		generatedDir, err := generator.PrepareGeneration(outputDirs[l])
+		if err != nil {
+			return errors.Wrapf(err, "failed to prepare %s package", l)
+		}
+		generatedDirs[l] = generatedDir
+	}
+
+	if len(batchGenerators) > 0 {
+		configs, err := packagegenerator.MergeConfigs(batchGenerators)
+		if err != nil {
+			return errors.Wrap(err, "failed to merge configs")
+		}
+		var args []string
+		if o.ServerVariables != "" {
+			args = append(args, "--server-variables="+o.ServerVariables)
+		}
+		err = packagegenerator.GenerateBatch(o.openAPIGenerator(), o.FileIO, configs, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, l := range languages {
+		var packageDir string
+		var err error
+		if generator, ok := batchGenerators[l]; ok {
+			log.Info().Msgf("%sCompleting %s client package%s", utils.Green, l, utils.Reset)
+			packageDir, err = generator.CompleteGeneration(outputDirs[l], generatedDirs[l])
+			if err == nil {
+				err = o.storeOutput(l, packageDir)
 			}
+		} else {
+			packageDir, err = o.generatePackage(l, outputDirs[l])
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate %s package", l)
+		}
+
+		err = o.pushPackage(l, packageDir)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -116,6 +220,27 @@ func (o *PackageOptions) Run(languages []string) error {
 	return nil
 }
 
+func (o *PackageOptions) openAPIGenerator() domain.OpenAPIGenerator {
+	if o.OpenAPIGenerator != nil {
+		return o.OpenAPIGenerator
+	}
+	return openapigenerator.NewNPXClient()
+}
+
+func (o *PackageOptions) pushPackage(l, packageDir string) error {
+	if o.SkipPush {
+		log.Info().Msgf("%sSkipping push for %s package (SKIP_PUSH=true)%s", utils.Yellow, l, utils.Reset)
+	} else {
+		log.Info().Msgf("%sPushing %s package%s", utils.Green, l, utils.Reset)
+		err := o.languageGenerators[l].PushPackage(packageDir)
+		if err != nil {
+			return errors.Wrapf(err, "failed to push %s package", l)
+		}
+		return o.markPublished(l)
+	}
+	return nil
+}
+
 func (o *PackageOptions) ValidateLanguages(languages []string) error {
 	for _, l := range languages {
 		if _, ok := o.languageGenerators[l]; !ok {
@@ -125,6 +250,15 @@ func (o *PackageOptions) ValidateLanguages(languages []string) error {
 	return nil
 }
 
+// Generator returns the initialised generator for the given language
+func (o *PackageOptions) Generator(language string) (domain.PackageGenerator, error) {
+	generator, ok := o.languageGenerators[language]
+	if !ok {
+		return nil, &domain.UnsupportedLanguageError{Language: language}
+	}
+	return generator, nil
+}
+
 func (o *PackageOptions) InitialiseGenerators() error {
 	o.languageGenerators = make(map[string]domain.PackageGenerator)
 
@@ -132,7 +266,7 @@ func (o *PackageOptions) InitialiseGenerators() error {
 
 	for _, language := range languages {
 		// Get the language-specific config
-		config, err := openapitools.GetConfigForLanguage(language)
+		config, err := openapitools.GetConfigForLanguage(o.Configs, language, o.Overrides)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get config for language %s", language)
 		}
@@ -141,6 +275,10 @@ func (o *PackageOptions) InitialiseGenerators() error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to create base generator for %s", language)
 		}
+		baseGenerator.Templates = o.Templates
+		if o.OpenAPIGenerator != nil {
+			baseGenerator.OpenAPIGenerator = o.OpenAPIGenerator
+		}
 
 		switch language {
 		case domain.Rust:
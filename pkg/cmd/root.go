@@ -3,8 +3,10 @@ package cmd
 import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	configcmd "github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/config"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/generate"
 	swagfiltercmd "github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/swagfilter"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/tag"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/test"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/cmd/version"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/rootcmd"
@@ -25,7 +27,9 @@ func Main() *cobra.Command {
 	}
 	cmd.AddCommand(generate.NewCmdGenerate())
 	cmd.AddCommand(test.NewCmdTest())
+	cmd.AddCommand(configcmd.NewCmdConfig())
 	cmd.AddCommand(swagfiltercmd.NewCmdSwagFilter())
+	cmd.AddCommand(tag.NewCmdTag())
 	cmd.AddCommand(cobras.SplitCommand(version.NewCmdVersion()))
 	return cmd
 }
@@ -0,0 +1,93 @@
+package tag
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/git"
+	_go "github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/go"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/rootcmd"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/helper"
+	"github.com/spring-financial-group/mqa-helpers/pkg/cobras/templates"
+)
+
+// GoPackagesOptions contains the options for the go-packages command
+type GoPackagesOptions struct {
+	RepoDir string
+	Git     domain.Gitter
+	FileIO  domain.FileIO
+}
+
+var (
+	tagLong = templates.LongDesc(`
+		Tags the packages merged into the package repositories.
+`)
+
+	goPackagesLong = templates.LongDesc(`
+		Tags the Go packages updated by the current commit of a clone of mqube-go-packages with their submodule
+		versions, e.g. mypackage/v1.2.3, and pushes the tags. Run it on the default branch once update pull requests
+		merge, so that go get can resolve each package version.
+`)
+
+	goPackagesExample = templates.Examples(`
+		# Tag the Go packages updated by the current commit of the repository in the working directory
+		%s tag go-packages
+	`)
+)
+
+// NewCmdTag creates a command object for the "tag" action, which tags merged packages
+func NewCmdTag() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Tags merged packages",
+		Long:  tagLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdTagGoPackages())
+	return cmd
+}
+
+// NewCmdTagGoPackages creates a command object for the "tag go-packages" action
+func NewCmdTagGoPackages() *cobra.Command {
+	o := &GoPackagesOptions{
+		Git:    git.NewClient(),
+		FileIO: file.NewFileIO(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "go-packages",
+		Short:   "Tags the Go packages updated by the current commit",
+		Long:    goPackagesLong,
+		Example: fmt.Sprintf(goPackagesExample, rootcmd.BinaryName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+		Aliases: []string{"go"},
+	}
+
+	cmd.Flags().StringVar(&o.RepoDir, "repo-dir", ".", "directory of the clone of mqube-go-packages")
+	return cmd
+}
+
+// Run implements this command
+func (o *GoPackagesOptions) Run() error {
+	tags, err := _go.TagMergedPackages(o.Git, o.FileIO, o.RepoDir)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		log.Info().Msg("No Go packages were updated by the current commit")
+		return nil
+	}
+	log.Info().Msgf("%sTagged %d Go packages%s", utils.Green, len(tags), utils.Reset)
+	return nil
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 )
@@ -24,6 +25,9 @@ type FileIO interface {
 	// CopyManyToDir copies multiple files from the source to the target directory
 	// preserving the file names
 	CopyManyToDir(dstDir string, srcFiles ...string) error
+	// CopyDir copies the contents of the source directory to the target directory, which must not contain any of the
+	// same files
+	CopyDir(srcDir string, dstDir string) error
 	// Move moves a file from the source to the target
 	Move(src string, dst string) error
 	// MkdirAll creates a directory and all its parents with the given permissions
@@ -36,11 +40,12 @@ type FileIO interface {
 	DeferRemove(path string)
 	// ReplaceInFile replaces the given string in the file at the given path
 	ReplaceInFile(path, old, new string) error
-	// TemplateFiles renders the given files using the given object and writes them to the given directory
-	TemplateFiles(dstDir string, obj any, filePaths ...string) error
-	// TemplateFilesInDir renders any files in the given source directory using the given object and writes them to the
-	// given destination directory using the same file names
-	TemplateFilesInDir(srcDir, dstDir string, obj any) error
+	// TemplateFiles renders the given files from the filesystem using the given object and writes them to the given
+	// directory
+	TemplateFiles(fsys fs.FS, dstDir string, obj any, filePaths ...string) error
+	// TemplateFilesInDir renders any files in the given source directory of the filesystem using the given object and
+	// writes them to the given destination directory using the same file names
+	TemplateFilesInDir(fsys fs.FS, srcDir, dstDir string, obj any) error
 }
 
 type FileNotFoundError struct {
@@ -12,6 +12,10 @@ type ScmClient interface {
 	RequestReviewers(ctx context.Context, reviewers []string, pullNumber int) (*github.PullRequest, error)
 	// AddLabels adds labels to a pull request given the pr number
 	AddLabels(ctx context.Context, labels []string, pullNumber int) ([]*github.Label, error)
+	// GetPullRequest gets a pull request given the pr number
+	GetPullRequest(ctx context.Context, pullNumber int) (*github.PullRequest, error)
+	// CreateTag creates a lightweight tag pointing at the given commit sha
+	CreateTag(ctx context.Context, tag, sha string) error
 }
 
 type Gitter interface {
@@ -23,6 +27,8 @@ type Gitter interface {
 	SetRemote(dir, repositoryURL string) error
 	// CheckoutBranch checks out a branch from the local env
 	CheckoutBranch(dir, branchName string) error
+	// Checkout checks out an existing branch, tracking the remote branch of the same name if there is one
+	Checkout(dir, branchName string) error
 	// AddFiles adds files to the local env
 	AddFiles(dir string, paths ...string) error
 	// Commit commits changes to the local env
@@ -31,4 +37,12 @@ type Gitter interface {
 	Push(dir, branch string) error
 	// GetDefaultBranchName gets the default branch name of the repo from the local env
 	GetDefaultBranchName(dir string) (string, error)
+	// CreateTag creates an annotated tag at the current commit
+	CreateTag(dir, tag, message string) error
+	// PushTag pushes a tag to the remote env
+	PushTag(dir, tag string) error
+	// TagPointsAtHead returns whether the tag exists and points at the current commit
+	TagPointsAtHead(dir, tag string) (bool, error)
+	// ChangedFiles returns the paths of the files added or modified between two commits, relative to the repository root
+	ChangedFiles(dir, from, to string) ([]string, error)
 }
@@ -30,6 +30,43 @@ func (_m *Gitter) AddFiles(dir string, paths ...string) error {
 	return r0
 }
 
+// ChangedFiles provides a mock function with given fields: dir, from, to
+func (_m *Gitter) ChangedFiles(dir string, from string, to string) ([]string, error) {
+	ret := _m.Called(dir, from, to)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, string) []string); ok {
+		r0 = rf(dir, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(dir, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Checkout provides a mock function with given fields: dir, branchName
+func (_m *Gitter) Checkout(dir string, branchName string) error {
+	ret := _m.Called(dir, branchName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(dir, branchName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckoutBranch provides a mock function with given fields: dir, branchName
 func (_m *Gitter) CheckoutBranch(dir string, branchName string) error {
 	ret := _m.Called(dir, branchName)
@@ -79,6 +116,20 @@ func (_m *Gitter) Commit(dir string, message string) error {
 	return r0
 }
 
+// CreateTag provides a mock function with given fields: dir, tag, message
+func (_m *Gitter) CreateTag(dir string, tag string, message string) error {
+	ret := _m.Called(dir, tag, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(dir, tag, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetCurrentBranch provides a mock function with given fields: dir
 func (_m *Gitter) GetCurrentBranch(dir string) (string, error) {
 	ret := _m.Called(dir)
@@ -135,6 +186,20 @@ func (_m *Gitter) Push(dir string, branch string) error {
 	return r0
 }
 
+// PushTag provides a mock function with given fields: dir, tag
+func (_m *Gitter) PushTag(dir string, tag string) error {
+	ret := _m.Called(dir, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(dir, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetRemote provides a mock function with given fields: dir, repositoryURL
 func (_m *Gitter) SetRemote(dir string, repositoryURL string) error {
 	ret := _m.Called(dir, repositoryURL)
@@ -149,6 +214,27 @@ func (_m *Gitter) SetRemote(dir string, repositoryURL string) error {
 	return r0
 }
 
+// TagPointsAtHead provides a mock function with given fields: dir, tag
+func (_m *Gitter) TagPointsAtHead(dir string, tag string) (bool, error) {
+	ret := _m.Called(dir, tag)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(dir, tag)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(dir, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewGitter interface {
 	mock.TestingT
 	Cleanup(func())
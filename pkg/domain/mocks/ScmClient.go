@@ -61,6 +61,43 @@ func (_m *ScmClient) CreatePullRequest(ctx context.Context, newPullRequest *gith
 	return r0, r1
 }
 
+// CreateTag provides a mock function with given fields: ctx, tag, sha
+func (_m *ScmClient) CreateTag(ctx context.Context, tag string, sha string) error {
+	ret := _m.Called(ctx, tag, sha)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tag, sha)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPullRequest provides a mock function with given fields: ctx, pullNumber
+func (_m *ScmClient) GetPullRequest(ctx context.Context, pullNumber int) (*github.PullRequest, error) {
+	ret := _m.Called(ctx, pullNumber)
+
+	var r0 *github.PullRequest
+	if rf, ok := ret.Get(0).(func(context.Context, int) *github.PullRequest); ok {
+		r0 = rf(ctx, pullNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, pullNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RequestReviewers provides a mock function with given fields: ctx, reviewers, pullNumber
 func (_m *ScmClient) RequestReviewers(ctx context.Context, reviewers []string, pullNumber int) (*github.PullRequest, error) {
 	ret := _m.Called(ctx, reviewers, pullNumber)
@@ -0,0 +1,28 @@
+package domain
+
+import "fmt"
+
+type OpenAPIGenerator interface {
+	// Generate runs openapi-generator for the generators with the given keys in the openapi-generator-cli config at
+	// configPath. Any extra arguments are passed straight through to openapi-generator.
+	Generate(configPath string, generatorKeys []string, extraArgs ...string) error
+}
+
+type GeneratorVersionMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *GeneratorVersionMismatchError) Error() string {
+	return fmt.Sprintf("openapi-generator version %s does not match the configured version %s", e.Actual, e.Expected)
+}
+
+type ChecksumMismatchError struct {
+	FilePath string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("sha256 checksum of %s is %s, expected %s", e.FilePath, e.Actual, e.Expected)
+}
@@ -1,12 +1,12 @@
 package domain
 
 type UVClient interface {
-	// Generate pyproject.toml file required for the build and publish steps
-	GeneratePyProjectFile(dir, packageName, packageVersion string) error
-
 	// Build the python project in the given directory
 	BuildProject(dir string) error
 
+	// Run the command from the given directory in an isolated environment with the given requirements installed
+	RunIsolated(dir string, requirements []string, command ...string) error
+
 	// Publish the python project in the given directory to the specified index (default: pyx)
 	PublishProject(dir string, indexName string) error
 }
@@ -3,7 +3,9 @@ package file
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -104,6 +106,11 @@ func (f FileIO) CopyToWorkingDir(srcPath string) (int64, error) {
 	return f.Copy(srcPath, filepath.Join(wd, filepath.Base(srcPath)))
 }
 
+func (f FileIO) CopyDir(srcDir string, dstDir string) error {
+	log.Info().Msg(fmt.Sprintf("%sCopying %s to %s%s", utils.Cyan, srcDir, dstDir, utils.Reset))
+	return os.CopyFS(dstDir, os.DirFS(srcDir))
+}
+
 func (f FileIO) Read(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
@@ -135,17 +142,17 @@ func (f FileIO) Remove(path string) error {
 	return os.RemoveAll(path)
 }
 
-func (f FileIO) TemplateFiles(dstDir string, obj any, filePaths ...string) error {
-	for _, path := range filePaths {
-		if err := f.templateFile(dstDir, obj, path); err != nil {
+func (f FileIO) TemplateFiles(fsys fs.FS, dstDir string, obj any, filePaths ...string) error {
+	for _, p := range filePaths {
+		if err := f.templateFile(fsys, dstDir, obj, p); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (f FileIO) TemplateFilesInDir(srcDir, dstDir string, obj any) error {
-	files, err := os.ReadDir(srcDir)
+func (f FileIO) TemplateFilesInDir(fsys fs.FS, srcDir, dstDir string, obj any) error {
+	files, err := fs.ReadDir(fsys, srcDir)
 	if err != nil {
 		return errors.Wrap(err, "failed to read directory")
 	}
@@ -155,22 +162,22 @@ func (f FileIO) TemplateFilesInDir(srcDir, dstDir string, obj any) error {
 			continue
 		}
 
-		if err = f.templateFile(dstDir, obj, filepath.Join(srcDir, file.Name())); err != nil {
+		if err = f.templateFile(fsys, dstDir, obj, path.Join(srcDir, file.Name())); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (f FileIO) templateFile(dstDir string, obj any, filePath string) error {
-	name := filepath.Base(filePath)
-	tmpl, err := template.ParseFiles(filePath)
+func (f FileIO) templateFile(fsys fs.FS, dstDir string, obj any, filePath string) error {
+	name := path.Base(filePath)
+	tmpl, err := template.ParseFS(fsys, filePath)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create template for %s", name)
 	}
 
-	path := filepath.Join(dstDir, filepath.Base(filePath))
-	file, err := os.Create(path)
+	dstPath := filepath.Join(dstDir, name)
+	file, err := os.Create(dstPath)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create new %s file", name)
 	}
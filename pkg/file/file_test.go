@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
 	"github.com/stretchr/testify/assert"
@@ -66,3 +67,31 @@ func TestFile_ReplaceInFile(t *testing.T) {
 		})
 	}
 }
+
+func TestFile_TemplateFilesInDir(t *testing.T) {
+	fileIO := file.NewFileIO()
+
+	templates := fstest.MapFS{
+		"java/build.gradle":  {Data: []byte("version = '{{ .Version }}'")},
+		"java/.npmrc":        {Data: []byte("//npm.pkg.github.com/:_authToken={{ .Token }}")},
+		"java/nested/ignore": {Data: []byte("not templated")},
+	}
+	obj := struct {
+		Version string
+		Token   string
+	}{Version: "1.2.3", Token: "abc"}
+
+	dstDir := t.TempDir()
+	err := fileIO.TemplateFilesInDir(templates, "java", dstDir, obj)
+	assert.NoError(t, err)
+
+	actual, err := os.ReadFile(filepath.Join(dstDir, "build.gradle"))
+	assert.NoError(t, err)
+	assert.Equal(t, "version = '1.2.3'", string(actual))
+
+	actual, err = os.ReadFile(filepath.Join(dstDir, ".npmrc"))
+	assert.NoError(t, err)
+	assert.Equal(t, "//npm.pkg.github.com/:_authToken=abc", string(actual))
+
+	assert.NoFileExists(t, filepath.Join(dstDir, "ignore"))
+}
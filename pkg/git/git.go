@@ -50,6 +50,12 @@ func (c *Client) CheckoutBranch(dir, branchName string) error {
 	return err
 }
 
+func (c *Client) Checkout(dir, branchName string) error {
+	out, err := c.git(dir, "checkout", branchName)
+	c.log(out)
+	return err
+}
+
 func (c *Client) AddFiles(dir string, paths ...string) error {
 	out, err := c.git(dir, append([]string{"add"}, paths...)...)
 	c.log(out)
@@ -74,6 +80,45 @@ func (c *Client) GetDefaultBranchName(dir string) (string, error) {
 	return out, err
 }
 
+func (c *Client) CreateTag(dir, tag, message string) error {
+	out, err := c.git(dir, "tag", "-a", tag, "-m", message)
+	c.log(out)
+	return err
+}
+
+func (c *Client) PushTag(dir, tag string) error {
+	out, err := c.git(dir, "push", "origin", "refs/tags/"+tag)
+	c.log(out)
+	return err
+}
+
+func (c *Client) TagPointsAtHead(dir, tag string) (bool, error) {
+	out, err := c.git(dir, "tag", "--points-at", "HEAD")
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == tag {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Client) ChangedFiles(dir, from, to string) ([]string, error) {
+	out, err := c.git(dir, "diff", "--name-only", "--diff-filter=d", from, to)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 func (c *Client) log(message string) {
 	if message != "" {
 		log.Info().Msg(message)
@@ -0,0 +1,271 @@
+// Package mockgen generates mocks for the interfaces in generated Go code without needing the mockery or mockgen
+// binaries
+package mockgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Style is the mocking library the generated mocks use
+type Style string
+
+const (
+	// Mockery generates testify mocks in the style of mockery, e.g. MockClientInterface with NewMockClientInterface(t)
+	Mockery Style = "mockery"
+	// GoMock generates gomock mocks in the style of mockgen, e.g. MockClientInterface with NewMockClientInterface(ctrl)
+	GoMock Style = "gomock"
+)
+
+const (
+	testifyMockImport = "github.com/stretchr/testify/mock"
+	goMockImport      = "go.uber.org/mock/gomock"
+	fileSuffix        = "_mock.go"
+)
+
+// ParseStyle returns the style for the given name
+func ParseStyle(name string) (Style, error) {
+	switch Style(name) {
+	case Mockery, GoMock:
+		return Style(name), nil
+	default:
+		return "", errors.Errorf("unsupported mock style %q, must be %s or %s", name, Mockery, GoMock)
+	}
+}
+
+// Generate returns a mock file for each interface declared in the Go source, keyed by file name, e.g.
+// client_interface_mock.go. The mocks are in the same package as the source.
+func Generate(src []byte, style Style) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse source")
+	}
+
+	files := make(map[string][]byte)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok || typeSpec.TypeParams != nil {
+				continue
+			}
+
+			m, err := newMock(fset, file, typeSpec.Name.Name, iface)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read interface %s", typeSpec.Name.Name)
+			}
+			code, err := m.render(style)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to generate mock for %s", typeSpec.Name.Name)
+			}
+			files[snakeCase(typeSpec.Name.Name)+fileSuffix] = code
+		}
+	}
+	return files, nil
+}
+
+type param struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+type method struct {
+	Name    string
+	Params  []param
+	Results []string
+}
+
+type mock struct {
+	Package   string
+	Interface string
+	Imports   []string
+	// Library is the import of the mocking library, kept in its own group after the source imports
+	Library string
+	Methods []method
+}
+
+func newMock(fset *token.FileSet, file *ast.File, name string, iface *ast.InterfaceType) (*mock, error) {
+	m := &mock{
+		Package:   file.Name.Name,
+		Interface: name,
+	}
+
+	usedPackages := make(map[string]bool)
+	declared := declaredNames(file)
+	// unqualified is whether the signatures use identifiers that aren't builtins or declared in the source, which come
+	// from its dot imports
+	unqualified := false
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok {
+				usedPackages[ident.Name] = true
+			}
+			return false
+		case *ast.Field:
+			// Only the types of the parameters and fields of func, struct and interface types, not their names
+			ast.Inspect(n.Type, inspect)
+			return false
+		case *ast.Ident:
+			if !declared[n.Name] && types.Universe.Lookup(n.Name) == nil {
+				unqualified = true
+			}
+		}
+		return true
+	}
+	typeString := func(expr ast.Expr) (string, error) {
+		ast.Inspect(expr, inspect)
+		var buf bytes.Buffer
+		err := printer.Fprint(&buf, fset, expr)
+		return buf.String(), err
+	}
+
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, errors.New("embedded interfaces are not supported")
+		}
+
+		meth := method{Name: field.Names[0].Name}
+		for _, p := range funcType.Params.List {
+			typ := p.Type
+			variadic := false
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ = ellipsis.Elt
+				variadic = true
+			}
+			typeStr, err := typeString(typ)
+			if err != nil {
+				return nil, err
+			}
+
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{{Name: "_"}}
+			}
+			for _, n := range names {
+				paramName := n.Name
+				if paramName == "_" {
+					paramName = fmt.Sprintf("_a%d", len(meth.Params))
+				}
+				meth.Params = append(meth.Params, param{Name: paramName, Type: typeStr, Variadic: variadic})
+			}
+		}
+		if funcType.Results != nil {
+			for _, r := range funcType.Results.List {
+				typeStr, err := typeString(r.Type)
+				if err != nil {
+					return nil, err
+				}
+				count := max(len(r.Names), 1)
+				for range count {
+					meth.Results = append(meth.Results, typeStr)
+				}
+			}
+		}
+		m.Methods = append(m.Methods, meth)
+	}
+
+	// Keep the imports of the source that the method signatures use, including its dot imports if they use identifiers
+	// the source doesn't declare
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || !usedPackages[name] && !(name == "." && unqualified) {
+			continue
+		}
+		if imp.Name != nil {
+			m.Imports = append(m.Imports, imp.Name.Name+" "+imp.Path.Value)
+		} else {
+			m.Imports = append(m.Imports, imp.Path.Value)
+		}
+	}
+	sort.Strings(m.Imports)
+	return m, nil
+}
+
+// declaredNames returns the names of the types declared in the source
+func declaredNames(file *ast.File) map[string]bool {
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			declared[spec.(*ast.TypeSpec).Name.Name] = true
+		}
+	}
+	return declared
+}
+
+func (m *mock) render(style Style) ([]byte, error) {
+	var tmpl *template.Template
+	switch style {
+	case Mockery:
+		tmpl = mockeryTemplate
+		m.Library = strconv.Quote(testifyMockImport)
+	case GoMock:
+		tmpl = goMockTemplate
+		m.Imports = append(m.Imports, strconv.Quote("reflect"))
+		sort.Strings(m.Imports)
+		m.Library = strconv.Quote(goMockImport)
+	default:
+		return nil, errors.Errorf("unsupported mock style %q", style)
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, m)
+	if err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format mock")
+	}
+	return code, nil
+}
+
+// snakeCase converts a Go identifier to snake case, treating runs of capitals as one word, e.g. HTTPClient ->
+// http_client
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startOfWord := i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+			if startOfWord {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
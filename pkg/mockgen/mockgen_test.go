@@ -0,0 +1,252 @@
+//go:build unit
+
+package mockgen_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/mockgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSource = `package petstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+type ClientInterface interface {
+	GetPet(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	CreatePetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+type HTTPRequestDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type Closer interface {
+	Close()
+}
+
+func unused() string { return strings.ToUpper("unused") }
+`
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		style            mockgen.Style
+		expectedFiles    []string
+		expectedContents map[string][]string
+		unexpected       []string
+	}{
+		{
+			name:          "Mockery",
+			style:         mockgen.Mockery,
+			expectedFiles: []string{"client_interface_mock.go", "http_request_doer_mock.go", "closer_mock.go"},
+			expectedContents: map[string][]string{
+				"client_interface_mock.go": {
+					"// Code generated by jx3-openapi-generation. DO NOT EDIT.",
+					"package petstore",
+					`"github.com/stretchr/testify/mock"`,
+					"type MockClientInterface struct {\n\tmock.Mock\n}",
+					"func (_m *MockClientInterface) GetPet(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {",
+					"_ca = append(_ca, ctx, id)",
+					"r1 = ret.Error(1)",
+					"func NewMockClientInterface(t interface {",
+				},
+				"http_request_doer_mock.go": {
+					"func (_m *MockHTTPRequestDoer) Do(_a0 *http.Request) (*http.Response, error) {",
+					"ret := _m.Called(_a0)",
+				},
+				"closer_mock.go": {
+					"func (_m *MockCloser) Close() {\n\t_m.Called()\n}",
+				},
+			},
+			unexpected: []string{`"strings"`, `"go.uber.org/mock/gomock"`},
+		},
+		{
+			name:          "GoMock",
+			style:         mockgen.GoMock,
+			expectedFiles: []string{"client_interface_mock.go", "http_request_doer_mock.go", "closer_mock.go"},
+			expectedContents: map[string][]string{
+				"client_interface_mock.go": {
+					"// Code generated by jx3-openapi-generation. DO NOT EDIT.",
+					`"go.uber.org/mock/gomock"`,
+					"func NewMockClientInterface(ctrl *gomock.Controller) *MockClientInterface {",
+					"func (mr *MockClientInterfaceMockRecorder) GetPet(ctx any, id any, reqEditors ...any) *gomock.Call {",
+					"varargs := []any{ctx, id}",
+					"ret0, _ := ret[0].(*http.Response)",
+				},
+				"http_request_doer_mock.go": {
+					`m.ctrl.Call(m, "Do", _a0)`,
+				},
+			},
+			unexpected: []string{`"strings"`, `"github.com/stretchr/testify/mock"`},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := mockgen.Generate([]byte(testSource), tt.style)
+			require.NoError(t, err)
+
+			var names []string
+			for name, code := range files {
+				names = append(names, name)
+
+				_, err = parser.ParseFile(token.NewFileSet(), name, code, parser.AllErrors)
+				assert.NoError(t, err)
+				for _, s := range tt.unexpected {
+					assert.NotContains(t, string(code), s)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedFiles, names)
+
+			for name, contents := range tt.expectedContents {
+				for _, s := range contents {
+					assert.Contains(t, string(files[name]), s)
+				}
+			}
+		})
+	}
+}
+
+const dotImportedSource = `package petstore
+
+type ListPetsParams struct {
+	Limit *int
+}
+`
+
+const dotImportSource = `package server
+
+import (
+	"net/http"
+
+	. "example.com/petstore"
+)
+
+type Middleware func(http.Handler) http.Handler
+
+type ServerInterface interface {
+	ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams)
+	GetPet(w http.ResponseWriter, r *http.Request, id string) error
+}
+
+type MiddlewareProvider interface {
+	Middlewares(handler func(w http.ResponseWriter, r *http.Request)) []Middleware
+}
+`
+
+// packageImporter imports the given packages, and any others from source
+type packageImporter struct {
+	packages map[string]*types.Package
+	source   types.ImporterFrom
+}
+
+func (i packageImporter) Import(path string) (*types.Package, error) {
+	return i.ImportFrom(path, "", 0)
+}
+
+func (i packageImporter) ImportFrom(path, dir string, mode types.ImportMode) (*types.Package, error) {
+	if pkg, ok := i.packages[path]; ok {
+		return pkg, nil
+	}
+	return i.source.ImportFrom(path, dir, mode)
+}
+
+func TestGenerate_DotImports(t *testing.T) {
+	fset := token.NewFileSet()
+	dotImported, err := parser.ParseFile(fset, "petstore.go", dotImportedSource, 0)
+	require.NoError(t, err)
+	petstore, err := (&types.Config{}).Check("example.com/petstore", fset, []*ast.File{dotImported}, nil)
+	require.NoError(t, err)
+
+	files, err := mockgen.Generate([]byte(dotImportSource), mockgen.Mockery)
+	require.NoError(t, err)
+	assert.Contains(t, string(files["server_interface_mock.go"]), `. "example.com/petstore"`)
+	assert.NotContains(t, string(files["middleware_provider_mock.go"]), `. "example.com/petstore"`)
+
+	// Type check the mocks in this directory, so that the mocking library is imported from the module
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	source, err := parser.ParseFile(fset, filepath.Join(wd, "server.go"), dotImportSource, 0)
+	require.NoError(t, err)
+	server := []*ast.File{source}
+	for name, code := range files {
+		mockFile, err := parser.ParseFile(fset, filepath.Join(wd, name), code, 0)
+		require.NoError(t, err)
+		server = append(server, mockFile)
+	}
+	cfg := &types.Config{Importer: packageImporter{
+		packages: map[string]*types.Package{"example.com/petstore": petstore},
+		source:   importer.ForCompiler(fset, "source", nil).(types.ImporterFrom),
+	}}
+	_, err = cfg.Check("example.com/petstore/server", fset, server, nil)
+	assert.NoError(t, err)
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first, err := mockgen.Generate([]byte(testSource), mockgen.Mockery)
+	require.NoError(t, err)
+	second, err := mockgen.Generate([]byte(testSource), mockgen.Mockery)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		style  mockgen.Style
+		errMsg string
+	}{
+		{
+			name:   "Invalid source",
+			src:    "package petstore\n\ntype",
+			style:  mockgen.Mockery,
+			errMsg: "failed to parse source",
+		},
+		{
+			name:   "Embedded interface",
+			src:    "package petstore\n\nimport \"io\"\n\ntype ReadCloser interface {\n\tio.Reader\n\tClose() error\n}\n",
+			style:  mockgen.Mockery,
+			errMsg: "embedded interfaces are not supported",
+		},
+		{
+			name:   "Unsupported style",
+			src:    testSource,
+			style:  "moq",
+			errMsg: `unsupported mock style "moq"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mockgen.Generate([]byte(tt.src), tt.style)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := mockgen.ParseStyle("gomock")
+	require.NoError(t, err)
+	assert.Equal(t, mockgen.GoMock, style)
+
+	_, err = mockgen.ParseStyle("moq")
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package mockgen
+
+import (
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"params": func(params []param) string {
+		parts := make([]string, len(params))
+		for i, p := range params {
+			if p.Variadic {
+				parts[i] = p.Name + " ..." + p.Type
+			} else {
+				parts[i] = p.Name + " " + p.Type
+			}
+		}
+		return strings.Join(parts, ", ")
+	},
+	"anyParams": func(params []param) string {
+		parts := make([]string, len(params))
+		for i, p := range params {
+			if p.Variadic {
+				parts[i] = p.Name + " ...any"
+			} else {
+				parts[i] = p.Name + " any"
+			}
+		}
+		return strings.Join(parts, ", ")
+	},
+	"results": func(results []string) string {
+		if len(results) == 0 {
+			return ""
+		}
+		if len(results) == 1 {
+			return results[0]
+		}
+		return "(" + strings.Join(results, ", ") + ")"
+	},
+	"variadic": func(params []param) bool {
+		return len(params) > 0 && params[len(params)-1].Variadic
+	},
+	"fixed": func(params []param) []param {
+		if len(params) > 0 && params[len(params)-1].Variadic {
+			return params[:len(params)-1]
+		}
+		return params
+	},
+	"last": func(params []param) param {
+		return params[len(params)-1]
+	},
+	"isError": func(typ string) bool {
+		return typ == "error"
+	},
+}
+
+var mockeryTemplate = template.Must(template.New("mockery").Funcs(funcs).Parse(`// Code generated by jx3-openapi-generation. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+{{if .Imports}}
+{{end}}	{{.Library}}
+)
+
+// Mock{{.Interface}} is a mock type for the {{.Interface}} type
+type Mock{{.Interface}} struct {
+	mock.Mock
+}
+{{range $m := .Methods}}
+// {{.Name}} provides a mock function
+func (_m *Mock{{$.Interface}}) {{.Name}}({{params .Params}}) {{results .Results}} {
+{{- if variadic .Params}}
+	_va := make([]interface{}, len({{(last .Params).Name}}))
+	for _i := range {{(last .Params).Name}} {
+		_va[_i] = {{(last .Params).Name}}[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca{{range fixed .Params}}, {{.Name}}{{end}})
+	_ca = append(_ca, _va...)
+	{{if .Results}}ret := {{end}}_m.Called(_ca...)
+{{- else}}
+	{{if .Results}}ret := {{end}}_m.Called({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end}})
+{{- end}}
+{{- range $i, $r := .Results}}
+
+	var r{{$i}} {{$r}}
+	{{- if isError $r}}
+	r{{$i}} = ret.Error({{$i}})
+	{{- else}}
+	if ret.Get({{$i}}) != nil {
+		r{{$i}} = ret.Get({{$i}}).({{$r}})
+	}
+	{{- end}}
+{{- end}}
+{{- if .Results}}
+
+	return {{range $i, $r := .Results}}{{if $i}}, {{end}}r{{$i}}{{end}}
+{{- end}}
+}
+{{end}}
+// NewMock{{.Interface}} creates a new instance of Mock{{.Interface}}. It also registers a testing interface on the
+// mock and a cleanup function to assert the mocks expectations.
+func NewMock{{.Interface}}(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Mock{{.Interface}} {
+	m := &Mock{{.Interface}}{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+`))
+
+var goMockTemplate = template.Must(template.New("gomock").Funcs(funcs).Parse(`// Code generated by jx3-openapi-generation. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+{{if .Imports}}
+{{end}}	{{.Library}}
+)
+
+// Mock{{.Interface}} is a mock of {{.Interface}} interface
+type Mock{{.Interface}} struct {
+	ctrl     *gomock.Controller
+	recorder *Mock{{.Interface}}MockRecorder
+}
+
+// Mock{{.Interface}}MockRecorder is the mock recorder for Mock{{.Interface}}
+type Mock{{.Interface}}MockRecorder struct {
+	mock *Mock{{.Interface}}
+}
+
+// NewMock{{.Interface}} creates a new mock instance
+func NewMock{{.Interface}}(ctrl *gomock.Controller) *Mock{{.Interface}} {
+	mock := &Mock{{.Interface}}{ctrl: ctrl}
+	mock.recorder = &Mock{{.Interface}}MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *Mock{{.Interface}}) EXPECT() *Mock{{.Interface}}MockRecorder {
+	return m.recorder
+}
+{{range $m := .Methods}}
+// {{.Name}} mocks base method
+func (m *Mock{{$.Interface}}) {{.Name}}({{params .Params}}) {{results .Results}} {
+	m.ctrl.T.Helper()
+{{- if variadic .Params}}
+	varargs := []any{ {{- range $i, $p := fixed .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end -}} }
+	for _, a := range {{(last .Params).Name}} {
+		varargs = append(varargs, a)
+	}
+	{{if .Results}}ret := {{end}}m.ctrl.Call(m, "{{.Name}}", varargs...)
+{{- else}}
+	{{if .Results}}ret := {{end}}m.ctrl.Call(m, "{{.Name}}"{{range .Params}}, {{.Name}}{{end}})
+{{- end}}
+{{- range $i, $r := .Results}}
+	ret{{$i}}, _ := ret[{{$i}}].({{$r}})
+{{- end}}
+{{- if .Results}}
+	return {{range $i, $r := .Results}}{{if $i}}, {{end}}ret{{$i}}{{end}}
+{{- end}}
+}
+
+// {{.Name}} indicates an expected call of {{.Name}}
+func (mr *Mock{{$.Interface}}MockRecorder) {{.Name}}({{anyParams .Params}}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+{{- if variadic .Params}}
+	varargs := append([]any{ {{- range $i, $p := fixed .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end -}} }, {{(last .Params).Name}}...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "{{.Name}}", reflect.TypeOf((*Mock{{$.Interface}})(nil).{{.Name}}), varargs...)
+{{- else}}
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "{{.Name}}", reflect.TypeOf((*Mock{{$.Interface}})(nil).{{.Name}}){{range .Params}}, {{.Name}}{{end}})
+{{- end}}
+}
+{{end}}`))
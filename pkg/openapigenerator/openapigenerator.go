@@ -0,0 +1,139 @@
+package openapigenerator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// NPXClient runs openapi-generator through the @openapitools/openapi-generator-cli wrapper, which downloads the
+// configured generator version on first use
+type NPXClient struct {
+	cmd domain.CommandRunner
+}
+
+func NewNPXClient() domain.OpenAPIGenerator {
+	return &NPXClient{
+		cmd: commandrunner.NewCommandRunner(),
+	}
+}
+
+func (c *NPXClient) Generate(configPath string, generatorKeys []string, extraArgs ...string) error {
+	args := []string{"@openapitools/openapi-generator-cli", "generate", "--generator-key", strings.Join(generatorKeys, ","), "--config", configPath}
+	args = append(args, extraArgs...)
+	return c.cmd.ExecuteAndLog("", "npx", args...)
+}
+
+// JarClient runs a local openapi-generator-cli JAR with java -jar so that generation is reproducible and works offline
+type JarClient struct {
+	Cmd     domain.CommandRunner
+	JarPath string
+
+	version string
+}
+
+// NewJarClient returns a client for the JAR at jarPath after verifying it against the expected sha256 checksum
+func NewJarClient(jarPath, checksum string) (domain.OpenAPIGenerator, error) {
+	if checksum == "" {
+		return nil, errors.Errorf("a sha256 checksum is required for %s", jarPath)
+	}
+	actual, err := sha256File(jarPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate checksum of generator jar")
+	}
+	if !strings.EqualFold(actual, checksum) {
+		return nil, &domain.ChecksumMismatchError{FilePath: jarPath, Expected: checksum, Actual: actual}
+	}
+	log.Info().Msgf("%sVerified openapi-generator jar %s%s", utils.Cyan, jarPath, utils.Reset)
+
+	return &JarClient{
+		Cmd:     commandrunner.NewCommandRunner(),
+		JarPath: jarPath,
+	}, nil
+}
+
+// Generate runs the jar once for each of the generators, as java -jar only accepts the options of a single generator.
+// Disabled generators are skipped, as they are by openapi-generator-cli.
+func (c *JarClient) Generate(configPath string, generatorKeys []string, extraArgs ...string) error {
+	cfg, err := openapitools.ReadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkVersion(cfg.GeneratorCLI.Version)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range generatorKeys {
+		generator, ok := cfg.GeneratorCLI.Generators[key]
+		if !ok {
+			return errors.New("generator configuration not found for key: " + key)
+		}
+		if generator.IsDisabled() {
+			log.Info().Msgf("Skipping the %s generator as it is disabled", key)
+			continue
+		}
+		err = c.generate(key, generator, extraArgs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to run %s generator", key)
+		}
+	}
+	return nil
+}
+
+func (c *JarClient) generate(generatorKey string, generator *openapitools.Generator, extraArgs []string) error {
+	generatorArgs, err := generator.CLIArgs()
+	if err != nil {
+		return errors.Wrapf(err, "failed to translate %s generator config to arguments", generatorKey)
+	}
+	if len(generator.Files) > 0 {
+		log.Warn().Msgf("The files option of the %s generator is not supported when running the jar directly and will be ignored", generatorKey)
+	}
+
+	args := []string{"-jar", c.JarPath, "generate"}
+	args = append(args, generatorArgs...)
+	args = append(args, extraArgs...)
+	return c.Cmd.ExecuteAndLog("", "java", args...)
+}
+
+// checkVersion checks the jar is the generator version the config was written for
+func (c *JarClient) checkVersion(expected string) error {
+	if c.version == "" {
+		out, err := c.Cmd.Execute("", "java", "-jar", c.JarPath, "version")
+		if err != nil {
+			return errors.Wrapf(err, "failed to get openapi-generator version: %s", out)
+		}
+		// Only the last line is the version, the JVM may log warnings before it
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		c.version = strings.TrimSpace(lines[len(lines)-1])
+	}
+	if expected != "" && c.version != expected {
+		return &domain.GeneratorVersionMismatchError{Expected: expected, Actual: c.version}
+	}
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
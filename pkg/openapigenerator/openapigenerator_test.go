@@ -0,0 +1,108 @@
+//go:build unit
+
+package openapigenerator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `{
+  "$schema": "node_modules/@openapitools/openapi-generator-cli/config.schema.json",
+  "spaces": 2,
+  "generator-cli": {
+    "version": "7.10.0",
+    "generators": {
+      "java": {
+        "generatorName": "java",
+        "output": "/tmp/out",
+        "inputSpec": "/tmp/spec.json"
+      }
+    }
+  }
+}`
+
+func TestNewJarClient(t *testing.T) {
+	jarPath := filepath.Join(t.TempDir(), "openapi-generator-cli.jar")
+	require.NoError(t, os.WriteFile(jarPath, []byte("jar"), 0600))
+	// sha256 of "jar"
+	const checksum = "0163f1eea7894350060624d315234d40c508ab251ba121714e234503045faadd"
+
+	t.Run("Missing checksum", func(t *testing.T) {
+		_, err := openapigenerator.NewJarClient(jarPath, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		_, err := openapigenerator.NewJarClient(jarPath, "85f4c3ec5b7a4b1b4ec1ca2b6e7ebb4a4e5a5b2a1d4c4c3e0e0bb4d4fbe85d5a")
+		var mismatchErr *domain.ChecksumMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, checksum, mismatchErr.Actual)
+	})
+
+	t.Run("Checksum matches", func(t *testing.T) {
+		_, err := openapigenerator.NewJarClient(jarPath, checksum)
+		assert.NoError(t, err)
+	})
+}
+
+func TestJarClient_Generate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "openapitools.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0600))
+
+	t.Run("Runs the jar with the generator config as arguments", func(t *testing.T) {
+		cmd := mocks.NewCommandRunner(t)
+		cmd.On("Execute", "", "java", "-jar", "generator.jar", "version").Return("7.10.0", nil).Once()
+		cmd.On("ExecuteAndLog", "", "java", "-jar", "generator.jar", "generate",
+			"--generator-name=java", "--input-spec=/tmp/spec.json", "--output=/tmp/out", "--server-variables=a=b").Return(nil).Once()
+
+		client := &openapigenerator.JarClient{Cmd: cmd, JarPath: "generator.jar"}
+		err := client.Generate(configPath, []string{"java"}, "--server-variables=a=b")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Skips disabled generators", func(t *testing.T) {
+		disabledConfigPath := filepath.Join(t.TempDir(), "openapitools.json")
+		disabledConfig := strings.Replace(testConfig, `"generatorName": "java",`, `"generatorName": "java",
+        "disabled": true,`, 1)
+		require.NoError(t, os.WriteFile(disabledConfigPath, []byte(disabledConfig), 0600))
+
+		cmd := mocks.NewCommandRunner(t)
+		cmd.On("Execute", "", "java", "-jar", "generator.jar", "version").Return("7.10.0", nil).Once()
+
+		client := &openapigenerator.JarClient{Cmd: cmd, JarPath: "generator.jar"}
+		err := client.Generate(disabledConfigPath, []string{"java"})
+		assert.NoError(t, err)
+		cmd.AssertNotCalled(t, "ExecuteAndLog", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Version mismatch", func(t *testing.T) {
+		cmd := mocks.NewCommandRunner(t)
+		cmd.On("Execute", "", "java", "-jar", "generator.jar", "version").Return("WARNING: something\n7.9.0", nil).Once()
+
+		client := &openapigenerator.JarClient{Cmd: cmd, JarPath: "generator.jar"}
+		err := client.Generate(configPath, []string{"java"})
+		var mismatchErr *domain.GeneratorVersionMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, "7.9.0", mismatchErr.Actual)
+		assert.Equal(t, "7.10.0", mismatchErr.Expected)
+	})
+
+	t.Run("Unknown generator", func(t *testing.T) {
+		cmd := mocks.NewCommandRunner(t)
+		cmd.On("Execute", "", "java", "-jar", "generator.jar", "version").Return("7.10.0", nil).Once()
+
+		client := &openapigenerator.JarClient{Cmd: cmd, JarPath: "generator.jar"}
+		err := client.Generate(configPath, []string{"rust"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package openapitools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// cliOnlyKeys are generator options that are used by openapi-generator-cli itself rather than passed to
+// openapi-generator
+var cliOnlyKeys = map[string]bool{
+	"disabled": true,
+	"glob":     true,
+	"files":    true,
+}
+
+// CLIArgs translates the generator config into openapi-generator generate arguments in the same way as
+// openapi-generator-cli. Objects are passed as comma separated key=value pairs, arrays as comma separated values and
+// booleans as flags when true. Keys and values in objects can't contain the separators, as they can't be escaped.
+func (g *Generator) CLIArgs() ([]string, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal generator")
+	}
+	var options map[string]any
+	err = json.Unmarshal(data, &options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal generator")
+	}
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		if !cliOnlyKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, key := range keys {
+		flag := "--" + kebabCase(key)
+		switch val := options[key].(type) {
+		case bool:
+			if val {
+				args = append(args, flag)
+			}
+		case map[string]any:
+			if len(val) > 0 {
+				pairs, err := joinKeyValues(val)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid %s", key)
+				}
+				args = append(args, flag+"="+pairs)
+			}
+		case string:
+			if val != "" {
+				args = append(args, flag+"="+val)
+			}
+		default:
+			value, err := formatValue(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s", key)
+			}
+			args = append(args, flag+"="+value)
+		}
+	}
+	return args, nil
+}
+
+func joinKeyValues(m map[string]any) (string, error) {
+	pairs := make([]string, 0, len(m))
+	for key, val := range m {
+		if strings.ContainsAny(key, ",=") {
+			return "", errors.Errorf("key %q can't contain , or =", key)
+		}
+		value, err := formatValue(val)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid %s", key)
+		}
+		if _, ok := val.([]any); !ok && strings.ContainsAny(value, ",=") {
+			return "", errors.Errorf("value %q of %s can't contain , or =", value, key)
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ","), nil
+}
+
+// formatValue formats a JSON value as openapi-generator-cli does, joining arrays with commas
+func formatValue(val any) (string, error) {
+	switch val := val.(type) {
+	case []any:
+		items := make([]string, len(val))
+		for i, item := range val {
+			switch item.(type) {
+			case []any, map[string]any:
+				return "", errors.New("nested arrays and objects are not supported")
+			}
+			value, err := formatValue(item)
+			if err != nil {
+				return "", err
+			}
+			if strings.ContainsAny(value, ",=") {
+				return "", errors.Errorf("array item %q can't contain , or =", value)
+			}
+			items[i] = value
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", errors.New("nested objects are not supported")
+	case nil:
+		return "", nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	default:
+		return fmt.Sprintf("%v", val), nil
+	}
+}
+
+// kebabCase converts a camelCase option name to its kebab-case flag name, e.g. generatorName -> generator-name
+func kebabCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package openapitools_test
+
+import (
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_CLIArgs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		generator    *openapitools.Generator
+		expectedArgs []string
+		expectedErr  string
+	}{
+		{
+			name: "Strings are passed as kebab-case flags",
+			generator: &openapitools.Generator{
+				Name:      "java",
+				Output:    "/tmp/out",
+				InputSpec: "/tmp/spec.json",
+				GitRepoID: "repo",
+			},
+			expectedArgs: []string{"--generator-name=java", "--git-repo-id=repo", "--input-spec=/tmp/spec.json", "--output=/tmp/out"},
+		},
+		{
+			name: "Booleans are flags when true",
+			generator: &openapitools.Generator{
+				Name:                    "java",
+				Output:                  "/tmp/out",
+				RemoveOperationIDPrefix: utils.NewPtr(true),
+				SkipValidateSpec:        utils.NewPtr(false),
+			},
+			expectedArgs: []string{"--generator-name=java", "--output=/tmp/out", "--remove-operation-id-prefix"},
+		},
+		{
+			name: "Objects are passed as sorted key value pairs",
+			generator: &openapitools.Generator{
+				Name:                 "java",
+				Output:               "/tmp/out",
+				AdditionalProperties: openapitools.Properties{"packageVersion": "1.0.0", "hideGenerationTimestamp": true},
+				GlobalProperty:       openapitools.Properties{},
+			},
+			expectedArgs: []string{"--additional-properties=hideGenerationTimestamp=true,packageVersion=1.0.0", "--generator-name=java", "--output=/tmp/out"},
+		},
+		{
+			name: "Arrays are passed as comma separated values",
+			generator: &openapitools.Generator{
+				Name:   "rust",
+				Output: "/tmp/out",
+				AdditionalProperties: openapitools.Properties{
+					"reqwestDefaultFeatures": []any{"rustls-tls"},
+					"packageVersion":         1000000,
+					"packageDescription":     nil,
+				},
+			},
+			expectedArgs: []string{"--additional-properties=packageDescription=,packageVersion=1000000,reqwestDefaultFeatures=rustls-tls", "--generator-name=rust", "--output=/tmp/out"},
+		},
+		{
+			name: "Arrays with several values",
+			generator: &openapitools.Generator{
+				Name:                 "rust",
+				Output:               "/tmp/out",
+				AdditionalProperties: openapitools.Properties{"reqwestDefaultFeatures": []any{"rustls-tls", "json"}},
+			},
+			expectedArgs: []string{"--additional-properties=reqwestDefaultFeatures=rustls-tls,json", "--generator-name=rust", "--output=/tmp/out"},
+		},
+		{
+			name: "Values containing a separator are rejected",
+			generator: &openapitools.Generator{
+				Name:                 "java",
+				Output:               "/tmp/out",
+				AdditionalProperties: openapitools.Properties{"licenseName": "Apache-2.0, MIT"},
+			},
+			expectedErr: `invalid additionalProperties: value "Apache-2.0, MIT" of licenseName can't contain , or =`,
+		},
+		{
+			name: "Array items containing a separator are rejected",
+			generator: &openapitools.Generator{
+				Name:           "java",
+				Output:         "/tmp/out",
+				GlobalProperty: openapitools.Properties{"models": []any{"Pet=Dog"}},
+			},
+			expectedErr: `invalid globalProperty: invalid models: array item "Pet=Dog" can't contain , or =`,
+		},
+		{
+			name: "Nested objects are rejected",
+			generator: &openapitools.Generator{
+				Name:                 "java",
+				Output:               "/tmp/out",
+				AdditionalProperties: openapitools.Properties{"nested": map[string]any{"a": "b"}},
+			},
+			expectedErr: "invalid additionalProperties: invalid nested: nested objects are not supported",
+		},
+		{
+			name: "CLI only options are not passed",
+			generator: &openapitools.Generator{
+				Name:     "java",
+				Output:   "/tmp/out",
+				Glob:     "*.json",
+				Disabled: utils.NewPtr(false),
+				Files:    map[string]openapitools.TemplateFile{"README.mustache": {}},
+			},
+			expectedArgs: []string{"--generator-name=java", "--output=/tmp/out"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := tt.generator.CLIArgs()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package openapitools
 
 import (
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -11,9 +12,9 @@ import (
 
 const (
 	OpenAPIConfigFileName = "openapitools.json"
-	ConfigsDir            = "/configs"
 )
 
+// Config is the openapi-generator-cli config, see openapitoolschema.json for the schema
 type Config struct {
 	Schema       string       `json:"$schema"`
 	Spaces       int          `json:"spaces"`
@@ -21,60 +22,150 @@ type Config struct {
 }
 
 type GeneratorCLI struct {
-	Version    string                `json:"version"`
-	Generators map[string]*Generator `json:"generators"`
+	Version         string                `json:"version"`
+	StorageDir      string                `json:"storageDir,omitempty"`
+	Repository      *Repository           `json:"repository,omitempty"`
+	UseDocker       *bool                 `json:"useDocker,omitempty"`
+	DockerImageName string                `json:"dockerImageName,omitempty"`
+	Generators      map[string]*Generator `json:"generators"`
 }
 
+type Repository struct {
+	QueryURL    string `json:"queryUrl,omitempty"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+// Generator is the config for a single generator. Booleans are pointers so that an explicit false is passed through
+// to openapi-generator rather than falling back to the generator's default.
 type Generator struct {
-	Name                    string            `json:"generatorName"`
-	Output                  string            `json:"output"`
-	InputSpec               string            `json:"inputSpec"`
-	GitRepoID               string            `json:"gitRepoId,omitempty"`
-	GitUserID               string            `json:"gitUserId,omitempty"`
-	EnablePostProcessFile   bool              `json:"enablePostProcessFile,omitempty"`
-	RemoveOperationIDPrefix bool              `json:"removeOperationIdPrefix,omitempty"`
-	GlobalProperty          map[string]string `json:"globalProperty,omitempty"`
-	AdditionalProperties    map[string]string `json:"additionalProperties,omitempty"`
+	Name                        string                  `json:"generatorName"`
+	Output                      string                  `json:"output"`
+	InputSpec                   string                  `json:"inputSpec,omitempty"`
+	Glob                        string                  `json:"glob,omitempty"`
+	Disabled                    *bool                   `json:"disabled,omitempty"`
+	Auth                        string                  `json:"auth,omitempty"`
+	APINameSuffix               string                  `json:"apiNameSuffix,omitempty"`
+	APIPackage                  string                  `json:"apiPackage,omitempty"`
+	ArtifactID                  string                  `json:"artifactId,omitempty"`
+	ArtifactVersion             string                  `json:"artifactVersion,omitempty"`
+	Config                      string                  `json:"config,omitempty"`
+	DryRun                      *bool                   `json:"dryRun,omitempty"`
+	Engine                      string                  `json:"engine,omitempty"`
+	EnablePostProcessFile       *bool                   `json:"enablePostProcessFile,omitempty"`
+	GenerateAliasAsModel        *bool                   `json:"generateAliasAsModel,omitempty"`
+	GitHost                     string                  `json:"gitHost,omitempty"`
+	GitRepoID                   string                  `json:"gitRepoId,omitempty"`
+	GitUserID                   string                  `json:"gitUserId,omitempty"`
+	GroupID                     string                  `json:"groupId,omitempty"`
+	HTTPUserAgent               string                  `json:"httpUserAgent,omitempty"`
+	IgnoreFileOverride          string                  `json:"ignoreFileOverride,omitempty"`
+	InvokerPackage              string                  `json:"invokerPackage,omitempty"`
+	LegacyDiscriminatorBehavior *bool                   `json:"legacyDiscriminatorBehavior,omitempty"`
+	Library                     string                  `json:"library,omitempty"`
+	LogToStderr                 *bool                   `json:"logToStderr,omitempty"`
+	MinimalUpdate               *bool                   `json:"minimalUpdate,omitempty"`
+	ModelNamePrefix             string                  `json:"modelNamePrefix,omitempty"`
+	ModelNameSuffix             string                  `json:"modelNameSuffix,omitempty"`
+	ModelPackage                string                  `json:"modelPackage,omitempty"`
+	PackageName                 string                  `json:"packageName,omitempty"`
+	ReleaseNote                 string                  `json:"releaseNote,omitempty"`
+	RemoveOperationIDPrefix     *bool                   `json:"removeOperationIdPrefix,omitempty"`
+	SkipOverwrite               *bool                   `json:"skipOverwrite,omitempty"`
+	SkipValidateSpec            *bool                   `json:"skipValidateSpec,omitempty"`
+	StrictSpec                  *bool                   `json:"strictSpec,omitempty"`
+	TemplateDir                 string                  `json:"templateDir,omitempty"`
+	Verbose                     *bool                   `json:"verbose,omitempty"`
+	GlobalProperty              Properties              `json:"globalProperty,omitempty"`
+	AdditionalProperties        Properties              `json:"additionalProperties,omitempty"`
+	ServerVariables             Properties              `json:"serverVariables,omitempty"`
+	TypeMappings                Properties              `json:"typeMappings,omitempty"`
+	ImportMappings              Properties              `json:"importMappings,omitempty"`
+	SchemaMappings              Properties              `json:"schemaMappings,omitempty"`
+	InstantiationTypes          Properties              `json:"instantiationTypes,omitempty"`
+	LanguageSpecificPrimitives  Properties              `json:"languageSpecificPrimitives,omitempty"`
+	ReservedWordsMappings       Properties              `json:"reservedWordsMappings,omitempty"`
+	NameMappings                Properties              `json:"nameMappings,omitempty"`
+	ParameterNameMappings       Properties              `json:"parameterNameMappings,omitempty"`
+	ModelNameMappings           Properties              `json:"modelNameMappings,omitempty"`
+	EnumNameMappings            Properties              `json:"enumNameMappings,omitempty"`
+	OperationIDNameMappings     Properties              `json:"operationIdNameMappings,omitempty"`
+	InlineSchemaNameMappings    Properties              `json:"inlineSchemaNameMappings,omitempty"`
+	InlineSchemaOptions         Properties              `json:"inlineSchemaOptions,omitempty"`
+	OpenAPINormalizer           Properties              `json:"openapiNormalizer,omitempty"`
+	Files                       map[string]TemplateFile `json:"files,omitempty"`
+}
+
+// IsDisabled returns whether the generator is disabled, in which case openapi-generator-cli skips it
+func (g *Generator) IsDisabled() bool {
+	return g.Disabled != nil && *g.Disabled
+}
+
+// TemplateFile is a user-defined template file to be rendered by openapi-generator
+type TemplateFile struct {
+	Folder              string `json:"folder,omitempty"`
+	DestinationFilename string `json:"destinationFilename,omitempty"`
+	TemplateType        string `json:"templateType,omitempty"`
 }
 
-func GetConfigForLanguage(language string) (*Config, error) {
+// GetConfigForLanguage reads the config for the given language from the configs filesystem and merges in any
+// overrides for the language
+func GetConfigForLanguage(configs fs.FS, language string, overrides Overrides) (*Config, error) {
 	cfg := new(Config)
 	fileName := language + "-" + OpenAPIConfigFileName
+	err := cfg.readFromFS(configs, fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read config from %s", fileName)
+	}
 
-	// Try relative path first (for local development)
-	configPath := filepath.Join("configs", fileName)
-	err := cfg.readFromFile(configPath)
+	err = overrides.apply(cfg, language)
 	if err != nil {
-		// Fall back to absolute path (for containerized environments)
-		configPath = filepath.Join(ConfigsDir, fileName)
-		err = cfg.readFromFile(configPath)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to read config from %s", configPath)
-		}
+		return nil, errors.Wrapf(err, "failed to apply overrides for %s", language)
 	}
 	return cfg, nil
 }
 
-func (c *Config) readFromFile(path string) error {
+// ReadConfig reads a config that has already been resolved and written, e.g. by WriteToCurrentWorkingDirectory
+func ReadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config file: "+path)
+	}
+	cfg := new(Config)
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config")
+	}
+	return cfg, nil
+}
+
+func (c *Config) readFromFS(fsys fs.FS, path string) error {
+	data, err := fs.ReadFile(fsys, path)
 	if err != nil {
 		return errors.Wrap(err, "failed to read config file: "+path)
 	}
+	err = validate(data)
+	if err != nil {
+		return errors.Wrap(err, "config does not match schema")
+	}
 	err = json.Unmarshal(data, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal config")
 	}
 
-	// Initialise the maps if they're nil so we can add to them
+	c.initialiseMaps()
+	return nil
+}
+
+// initialiseMaps initialises the maps if they're nil so we can add to them
+func (c *Config) initialiseMaps() {
 	for _, val := range c.GeneratorCLI.Generators {
 		if val.GlobalProperty == nil {
-			val.GlobalProperty = make(map[string]string)
+			val.GlobalProperty = make(Properties)
 		}
 		if val.AdditionalProperties == nil {
-			val.AdditionalProperties = make(map[string]string)
+			val.AdditionalProperties = make(Properties)
 		}
 	}
-	return nil
 }
 
 func (c *Config) WriteToCurrentWorkingDirectory() (string, error) {
@@ -0,0 +1,119 @@
+//go:build unit
+
+package openapitools_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfigForLanguage_EmbeddedConfigs(t *testing.T) {
+	languages := []string{domain.Rust, domain.CSharp, domain.Java, domain.Angular, domain.Python, domain.Javascript, domain.Typescript, domain.Go}
+
+	for _, language := range languages {
+		t.Run(language, func(t *testing.T) {
+			cfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), language, nil)
+			require.NoError(t, err)
+			assert.Contains(t, cfg.GeneratorCLI.Generators, language)
+		})
+	}
+}
+
+func TestGetConfigForLanguage_Types(t *testing.T) {
+	configs := fstest.MapFS{
+		"rust-openapitools.json": {Data: []byte(`{
+  "spaces": 2,
+  "generator-cli": {
+    "version": "7.15.0",
+    "generators": {
+      "rust": {
+        "generatorName": "rust",
+        "output": "./rust-service",
+        "inputSpec": "./mocks/swagger.json",
+        "removeOperationIdPrefix": false,
+        "additionalProperties": {
+          "library": "reqwest-trait",
+          "supportMiddleware": true,
+          "maxRetries": 3,
+          "reqwestDefaultFeatures": ["rustls-tls"]
+        },
+        "typeMappings": "DateTime=String,Date=String",
+        "nameMappings": {"_type": "kind"},
+        "openapiNormalizer": {"REF_AS_PARENT_IN_ALLOF": true},
+        "files": {
+          "custom.mustache": {"templateType": "SupportingFiles", "destinationFilename": "CUSTOM.md"}
+        }
+      }
+    }
+  }
+}`)},
+	}
+
+	cfg, err := openapitools.GetConfigForLanguage(configs, "rust", nil)
+	require.NoError(t, err)
+
+	gen := cfg.GeneratorCLI.Generators["rust"]
+	require.NotNil(t, gen)
+	require.NotNil(t, gen.RemoveOperationIDPrefix)
+	assert.False(t, *gen.RemoveOperationIDPrefix)
+	assert.Nil(t, gen.EnablePostProcessFile)
+	assert.Equal(t, openapitools.Properties{
+		"library":                "reqwest-trait",
+		"supportMiddleware":      true,
+		"maxRetries":             float64(3),
+		"reqwestDefaultFeatures": []any{"rustls-tls"},
+	}, gen.AdditionalProperties)
+	assert.Equal(t, openapitools.Properties{"DateTime": "String", "Date": "String"}, gen.TypeMappings)
+	assert.Equal(t, openapitools.Properties{"_type": "kind"}, gen.NameMappings)
+	assert.Equal(t, openapitools.Properties{"REF_AS_PARENT_IN_ALLOF": true}, gen.OpenAPINormalizer)
+	assert.Equal(t, map[string]openapitools.TemplateFile{
+		"custom.mustache": {TemplateType: "SupportingFiles", DestinationFilename: "CUSTOM.md"},
+	}, gen.Files)
+	assert.NotNil(t, gen.GlobalProperty)
+}
+
+func TestGetConfigForLanguage_Validation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    string
+		overrides openapitools.Overrides
+	}{
+		{
+			name:   "MissingGeneratorCLI",
+			config: `{"spaces": 2}`,
+		},
+		{
+			name:   "UnknownTopLevelKey",
+			config: `{"generator-cli": {"version": "7.15.0"}, "generators": {}}`,
+		},
+		{
+			name:   "MissingOutput",
+			config: `{"generator-cli": {"version": "7.15.0", "generators": {"java": {"generatorName": "java", "inputSpec": "spec.json"}}}}`,
+		},
+		{
+			name:   "WrongType",
+			config: `{"generator-cli": {"version": "7.15.0", "generators": {"java": {"generatorName": "java", "inputSpec": "spec.json", "output": "out", "skipValidateSpec": "yes"}}}}`,
+		},
+		{
+			name:   "InvalidOverride",
+			config: `{"generator-cli": {"version": "7.15.0", "generators": {"java": {"generatorName": "java", "inputSpec": "spec.json", "output": "out"}}}}`,
+			overrides: openapitools.Overrides{
+				"java": {"engine": "velocity"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configs := fstest.MapFS{"java-openapitools.json": {Data: []byte(tc.config)}}
+			_, err := openapitools.GetConfigForLanguage(configs, "java", tc.overrides)
+			assert.Error(t, err)
+		})
+	}
+}
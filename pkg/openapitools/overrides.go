@@ -0,0 +1,102 @@
+package openapitools
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// Overrides are per-service changes to the generator configs keyed by language. Each value has the same shape as a
+// generator in openapitools.json and is deep merged into it, objects are merged key by key, any other value replaces
+// the default and a null value removes the default.
+//
+//	{
+//	  "csharp": {"additionalProperties": {"library": "httpclient"}},
+//	  "java": {"typeMappings": {"DateTime": "OffsetDateTime"}}
+//	}
+type Overrides map[string]map[string]any
+
+// LoadOverrides reads the overrides from the file at the given path
+func LoadOverrides(path string) (Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read overrides file: "+path)
+	}
+
+	var overrides Overrides
+	err = json.Unmarshal(data, &overrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal overrides")
+	}
+	return overrides, nil
+}
+
+func (o Overrides) apply(cfg *Config, language string) error {
+	override, ok := o[language]
+	if !ok {
+		return nil
+	}
+
+	generator, ok := cfg.GeneratorCLI.Generators[language]
+	if !ok {
+		return errors.Errorf("no generator named %s to override", language)
+	}
+
+	data, err := json.Marshal(generator)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal generator")
+	}
+	var merged map[string]any
+	err = json.Unmarshal(data, &merged)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal generator")
+	}
+
+	mergeJSON(merged, override)
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal merged generator")
+	}
+	err = validateGenerator(cfg, language, data)
+	if err != nil {
+		return errors.Wrap(err, "overridden config does not match schema")
+	}
+	generator = new(Generator)
+	err = json.Unmarshal(data, generator)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal merged generator")
+	}
+	cfg.GeneratorCLI.Generators[language] = generator
+	cfg.initialiseMaps()
+
+	log.Info().Msgf("%sApplied per-service overrides to %s config%s", utils.Cyan, language, utils.Reset)
+	return nil
+}
+
+// mergeJSON deep merges src into dst. Nested objects are merged, null values delete the key from dst and anything
+// else replaces the value in dst.
+func mergeJSON(dst, src map[string]any) {
+	for key, srcVal := range src {
+		if srcVal == nil {
+			delete(dst, key)
+			continue
+		}
+
+		srcMap, ok := srcVal.(map[string]any)
+		if !ok {
+			dst[key] = srcVal
+			continue
+		}
+
+		dstMap, ok := dst[key].(map[string]any)
+		if !ok {
+			dstMap = make(map[string]any)
+			dst[key] = dstMap
+		}
+		mergeJSON(dstMap, srcMap)
+	}
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+package openapitools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCSharpConfig = `{
+  "$schema": "node_modules/@openapitools/openapi-generator-cli/config.schema.json",
+  "spaces": 2,
+  "generator-cli": {
+    "version": "7.1.0",
+    "generators": {
+      "csharp": {
+        "output": "./csharp-service",
+        "inputSpec": "./mocks/swagger.json",
+        "generatorName": "csharp",
+        "additionalProperties": {
+          "targetFramework": "netstandard2.1",
+          "library": "restsharp",
+          "equatable": "true"
+        }
+      }
+    }
+  }
+}`
+
+func TestGetConfigForLanguage_Overrides(t *testing.T) {
+	configs := fstest.MapFS{
+		"csharp-openapitools.json": {Data: []byte(testCSharpConfig)},
+	}
+
+	testCases := []struct {
+		name      string
+		overrides openapitools.Overrides
+		assertFn  func(t *testing.T, gen *openapitools.Generator)
+	}{
+		{
+			name:      "NoOverrides",
+			overrides: nil,
+			assertFn: func(t *testing.T, gen *openapitools.Generator) {
+				assert.Equal(t, "restsharp", gen.AdditionalProperties["library"])
+				assert.NotNil(t, gen.GlobalProperty)
+			},
+		},
+		{
+			name: "MergesAdditionalProperties",
+			overrides: openapitools.Overrides{
+				"csharp": {"additionalProperties": map[string]any{"library": "httpclient", "nullableReferenceTypes": "true"}},
+			},
+			assertFn: func(t *testing.T, gen *openapitools.Generator) {
+				assert.Equal(t, openapitools.Properties{
+					"targetFramework":        "netstandard2.1",
+					"library":                "httpclient",
+					"equatable":              "true",
+					"nullableReferenceTypes": "true",
+				}, gen.AdditionalProperties)
+			},
+		},
+		{
+			name: "NullRemovesDefault",
+			overrides: openapitools.Overrides{
+				"csharp": {"additionalProperties": map[string]any{"equatable": nil}},
+			},
+			assertFn: func(t *testing.T, gen *openapitools.Generator) {
+				assert.NotContains(t, gen.AdditionalProperties, "equatable")
+				assert.Equal(t, "restsharp", gen.AdditionalProperties["library"])
+			},
+		},
+		{
+			name: "AddsMappingsAndTemplateDir",
+			overrides: openapitools.Overrides{
+				"csharp": {
+					"globalProperty": map[string]any{"models": ""},
+					"typeMappings":   map[string]any{"DateTime": "DateTimeOffset"},
+					"importMappings": map[string]any{"DateTimeOffset": "System.DateTimeOffset"},
+					"schemaMappings": map[string]any{"Money": "Mqube.Common.Money"},
+					"templateDir":    "./templates/csharp",
+				},
+			},
+			assertFn: func(t *testing.T, gen *openapitools.Generator) {
+				assert.Equal(t, openapitools.Properties{"models": ""}, gen.GlobalProperty)
+				assert.Equal(t, openapitools.Properties{"DateTime": "DateTimeOffset"}, gen.TypeMappings)
+				assert.Equal(t, openapitools.Properties{"DateTimeOffset": "System.DateTimeOffset"}, gen.ImportMappings)
+				assert.Equal(t, openapitools.Properties{"Money": "Mqube.Common.Money"}, gen.SchemaMappings)
+				assert.Equal(t, "./templates/csharp", gen.TemplateDir)
+			},
+		},
+		{
+			name: "IgnoresOtherLanguages",
+			overrides: openapitools.Overrides{
+				"java": {"additionalProperties": map[string]any{"library": "native"}},
+			},
+			assertFn: func(t *testing.T, gen *openapitools.Generator) {
+				assert.Equal(t, "restsharp", gen.AdditionalProperties["library"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := openapitools.GetConfigForLanguage(configs, "csharp", tc.overrides)
+			require.NoError(t, err)
+
+			gen := cfg.GeneratorCLI.Generators["csharp"]
+			require.NotNil(t, gen)
+			assert.Equal(t, "csharp", gen.Name)
+			tc.assertFn(t, gen)
+		})
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	err := os.WriteFile(path, []byte(`{"csharp": {"additionalProperties": {"library": "httpclient"}}}`), 0600)
+	require.NoError(t, err)
+
+	overrides, err := openapitools.LoadOverrides(path)
+	require.NoError(t, err)
+	assert.Equal(t, openapitools.Overrides{
+		"csharp": {"additionalProperties": map[string]any{"library": "httpclient"}},
+	}, overrides)
+}
@@ -0,0 +1,40 @@
+package openapitools
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Properties are the key value options passed to a generator such as additionalProperties or typeMappings. Values can
+// be any JSON type so booleans and numbers are passed through as such. The schema also allows them to be given in the
+// CLI format of name=value,name=value which is parsed into the equivalent object.
+type Properties map[string]any
+
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*p = parseProperties(str)
+		return nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return errors.Wrap(err, "properties must be a string or an object")
+	}
+	*p = obj
+	return nil
+}
+
+func parseProperties(str string) Properties {
+	props := make(Properties)
+	for _, pair := range strings.Split(str, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return props
+}
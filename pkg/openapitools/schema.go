@@ -0,0 +1,70 @@
+package openapitools
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+)
+
+const schemaURL = "https://openapitools.org/openapi-generator-cli/config.schema.json"
+
+var compileSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(assets.ConfigSchema()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal schema")
+	}
+
+	compiler := jsonschema.NewCompiler()
+	err = compiler.AddResource(schemaURL, doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add schema")
+	}
+	return compiler.Compile(schemaURL)
+})
+
+// validate validates the openapitools.json data against the generator-cli config schema
+func validate(data []byte) error {
+	schema, err := compileSchema()
+	if err != nil {
+		return errors.Wrap(err, "failed to compile config schema")
+	}
+
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal config")
+	}
+	return schema.Validate(inst)
+}
+
+// validateGenerator validates the config with the given generator replaced by the generator data
+func validateGenerator(cfg *Config, language string, generator []byte) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config")
+	}
+
+	var doc map[string]any
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal config")
+	}
+	generatorCLI, ok := doc["generator-cli"].(map[string]any)
+	if !ok {
+		return errors.New("config has no generator-cli")
+	}
+	generators, ok := generatorCLI["generators"].(map[string]any)
+	if !ok {
+		return errors.New("config has no generators")
+	}
+	generators[language] = json.RawMessage(generator)
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config")
+	}
+	return validate(data)
+}
@@ -2,6 +2,7 @@ package angular
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -10,17 +11,18 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
 )
 
 const (
-	packagingFilesDir = "/templates/angular"
+	packagingFilesDir = "angular"
 )
 
 // Paths for use in generating angular packages
 var (
-	npmrcPath       = filepath.Join(packagingFilesDir, ".npmrc")
-	packageJSONPath = filepath.Join(packagingFilesDir, "package.json")
-	tsConfigPath    = filepath.Join(packagingFilesDir, "tsconfig.json")
+	npmrcPath       = path.Join(packagingFilesDir, ".npmrc")
+	packageJSONPath = path.Join(packagingFilesDir, "package.json")
+	tsConfigPath    = path.Join(packagingFilesDir, "tsconfig.json")
 )
 
 // Packages installed by the generator
@@ -44,27 +46,41 @@ func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 }
 
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
-	packageDir, err := g.BaseGenerator.GeneratePackage(filepath.Join(outputDir, g.GetPackageName()), domain.Angular)
+	generatedDir, err := g.PrepareGeneration(outputDir)
 	if err != nil {
 		return "", err
 	}
 
-	if err = g.FileIO.TemplateFiles(packageDir, g, packageJSONPath, tsConfigPath); err != nil {
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.Angular)
+	if err != nil {
+		return "", err
+	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
+
+// PrepareGeneration sets the output directory
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	return g.SetOutput(filepath.Join(outputDir, g.GetPackageName()), domain.Angular)
+}
+
+// CompleteGeneration compiles the generated package and templates the package files into the dist directory
+func (g *Generator) CompleteGeneration(outputDir, generatedDir string) (string, error) {
+	if err := g.FileIO.TemplateFiles(g.Templates, generatedDir, g, packageJSONPath, tsConfigPath); err != nil {
 		return "", err
 	}
 
-	err = g.installNPMPackages(packageDir, RXJS, Zone, AngularCore, AngularCommon)
+	err := g.installNPMPackages(generatedDir, RXJS, Zone, AngularCore, AngularCommon)
 	if err != nil {
 		return "", err
 	}
 
-	err = g.Cmd.ExecuteAndLog(packageDir, "ngc")
+	err = g.Cmd.ExecuteAndLog(generatedDir, "ngc")
 	if err != nil {
 		return "", errors.Wrap(err, "failed to run ngc")
 	}
 
 	distDir := filepath.Join(outputDir, "dist")
-	if err = g.FileIO.TemplateFiles(distDir, g, packageJSONPath, npmrcPath); err != nil {
+	if err = g.FileIO.TemplateFiles(g.Templates, distDir, g, packageJSONPath, npmrcPath); err != nil {
 		return "", err
 	}
 	return distDir, nil
@@ -85,15 +101,17 @@ func (g *Generator) GetPackageName() string {
 }
 
 func (g *Generator) PushPackage(packageDir string) error {
-	// Determine npm publish args - prerelease versions need a tag
-	var out string
-	var err error
-	if strings.Contains(g.Version, "-") {
-		// This is a prerelease version (e.g., contains -SNAPSHOT, -PR-, -alpha, etc.)
-		out, err = g.Cmd.Execute(packageDir, "npm", "publish", "--tag", "preview")
-	} else {
-		out, err = g.Cmd.Execute(packageDir, "npm", "publish")
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return err
+	}
+
+	// Prereleases (e.g. -SNAPSHOT, -PR-, -alpha) are published under their own dist-tag
+	args := []string{"publish"}
+	if distTag := v.NPMDistTag(); distTag != version.NPMLatestTag {
+		args = append(args, "--tag", distTag)
 	}
+	out, err := g.Cmd.Execute(packageDir, "npm", args...)
 	log.Info().Msg(out)
 	if err != nil {
 		// NPM returns the error message on STDOUT, so we need to check there for the error
@@ -112,11 +130,15 @@ func (g *Generator) PushPackage(packageDir string) error {
 }
 
 func (g *Generator) incrementPackageVersion(packageDir string) error {
-	currentV := g.Version
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return err
+	}
+	currentV := v.NPM()
 	newV := fmt.Sprintf("%s-%d", currentV, time.Now().Unix())
 
 	log.Info().Msgf("Incrementing version %s to %s", currentV, newV)
-	err := g.Cmd.ExecuteAndLog(packageDir, "npm", "version", newV)
+	err = g.Cmd.ExecuteAndLog(packageDir, "npm", "version", newV)
 	if err != nil {
 		return errors.Wrapf(err, "failed to increment version to %s", newV)
 	}
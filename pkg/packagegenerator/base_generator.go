@@ -1,13 +1,53 @@
 package packagegenerator
 
 import (
+	"io/fs"
+
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapigenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
 )
 
+// ConfigResolver is implemented by generators that generate packages with openapi-generator
+type ConfigResolver interface {
+	// ResolveConfig returns the openapi-generator config that will be used to generate the package
+	ResolveConfig() *openapitools.Config
+}
+
+// OpenAPIGeneratorUser is implemented by generators to say whether their packages are generated with openapi-generator.
+// Generators embedding BaseGenerator that generate their packages some other way override it.
+type OpenAPIGeneratorUser interface {
+	// UsesOpenAPIGenerator returns whether the package is generated with openapi-generator
+	UsesOpenAPIGenerator() bool
+}
+
+// OptionsProvider is implemented by generators with language specific options that change the generated package
+type OptionsProvider interface {
+	// GenerationOptions returns a description of each option
+	GenerationOptions() []string
+}
+
+// BatchGenerator is implemented by generators whose openapi-generator step can be run in a single invocation together
+// with other languages. GeneratePackage is equivalent to PrepareGeneration, running openapi-generator for the language
+// and then CompleteGeneration.
+type BatchGenerator interface {
+	domain.PackageGenerator
+	ConfigResolver
+	// PrepareGeneration runs the steps before openapi-generator, sets the output of the generator config and returns the
+	// directory openapi-generator generates into
+	PrepareGeneration(outputDir string) (string, error)
+	// CompleteGeneration runs the steps after openapi-generator has generated into generatedDir and returns the package
+	// directory
+	CompleteGeneration(outputDir, generatedDir string) (string, error)
+}
+
 type BaseGenerator struct {
 	Version         string
 	ServiceName     string
@@ -19,60 +59,68 @@ type BaseGenerator struct {
 	PackageName     string
 	ServerVariables string
 
-	Cfg    *openapitools.Config
-	Cmd    domain.CommandRunner
-	FileIO domain.FileIO
+	Cfg *openapitools.Config
+	// Templates holds the packaging templates for each language, keyed by language directory
+	Templates        fs.FS
+	Cmd              domain.CommandRunner
+	FileIO           domain.FileIO
+	OpenAPIGenerator domain.OpenAPIGenerator
 }
 
 func NewBaseGenerator(version, serviceName, repoOwner, repoName, gitToken, gitUser, specPath, packageName, serverVariables string, cfg *openapitools.Config) (*BaseGenerator, error) {
 	gen := &BaseGenerator{
-		Version:         version,
-		ServiceName:     serviceName,
-		RepoOwner:       repoOwner,
-		RepoName:        repoName,
-		GitUser:         gitUser,
-		GitToken:        gitToken,
-		SpecPath:        specPath,
-		PackageName:     packageName,
-		ServerVariables: serverVariables,
-		Cmd:             commandrunner.NewCommandRunner(),
-		FileIO:          file.NewFileIO(),
-		Cfg:             cfg,
+		Version:          version,
+		ServiceName:      serviceName,
+		RepoOwner:        repoOwner,
+		RepoName:         repoName,
+		GitUser:          gitUser,
+		GitToken:         gitToken,
+		SpecPath:         specPath,
+		PackageName:      packageName,
+		ServerVariables:  serverVariables,
+		Cmd:              commandrunner.NewCommandRunner(),
+		FileIO:           file.NewFileIO(),
+		OpenAPIGenerator: openapigenerator.NewNPXClient(),
+		Templates:        assets.DefaultTemplates(),
+		Cfg:              cfg,
 	}
 
-	// Set dynamic config variables
-	for _, val := range gen.Cfg.GeneratorCLI.Generators {
+	// Set dynamic config variables. Versions that aren't semver are set as given, so that the package tooling reports
+	// the problem.
+	parsedVersion, _ := gen.ParsedVersion()
+	for language, val := range gen.Cfg.GeneratorCLI.Generators {
 		val.InputSpec = gen.SpecPath
 		val.GitRepoID = gen.RepoName
 		val.GitUserID = gen.RepoOwner
 		val.AdditionalProperties["packageVersion"] = gen.Version
+		if parsedVersion != nil {
+			val.AdditionalProperties["packageVersion"] = packageVersion(parsedVersion, language)
+		}
 	}
 
 	return gen, nil
 }
 
+// ResolveConfig returns the openapi-generator config that will be used to generate the package. Generators that set
+// language specific variables override this to apply them first.
+func (g *BaseGenerator) ResolveConfig() *openapitools.Config {
+	return g.Cfg
+}
+
+// UsesOpenAPIGenerator returns true, as the base generator generates packages with openapi-generator
+func (g *BaseGenerator) UsesOpenAPIGenerator() bool {
+	return true
+}
+
 // GeneratePackage generates the package for the given language using the openapi-generator-cli. The config is written
 // to the directory before running the command.
 func (g *BaseGenerator) GeneratePackage(outputDir, language string) (string, error) {
-	_, err := g.FileIO.MkdirAll(outputDir, 0755)
+	_, err := g.SetOutput(outputDir, language)
 	if err != nil {
 		return "", err
 	}
+	g.logEffectiveConfig(language)
 
-	// Find the generator config for this language
-	var generator *openapitools.Generator
-	for key, gen := range g.Cfg.GeneratorCLI.Generators {
-		if key == language {
-			generator = gen
-			break
-		}
-	}
-
-	if generator == nil {
-		return "", errors.New("generator configuration not found for language: " + language)
-	}
-
-	generator.Output = outputDir
 	cfgPath, err := g.Cfg.WriteToCurrentWorkingDirectory()
 	if err != nil {
 		return "", err
@@ -80,17 +128,67 @@ func (g *BaseGenerator) GeneratePackage(outputDir, language string) (string, err
 	defer g.FileIO.DeferRemove(cfgPath)
 
 	// Generate Package
+	err = g.OpenAPIGenerator.Generate(cfgPath, []string{language}, g.ServerVariablesArgs()...)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate package")
+	}
+	return outputDir, nil
+}
 
-	args := []string{"@openapitools/openapi-generator-cli", "generate", "--generator-key", language, "--config", cfgPath}
+// ParsedVersion returns the package version, which generators and packaging templates render in the format of their
+// ecosystem, e.g. {{ .ParsedVersion.Maven }}
+func (g *BaseGenerator) ParsedVersion() (*version.Version, error) {
+	return version.Parse(g.Version)
+}
 
-	if g.ServerVariables != "" {
-		args = append(args, "--server-variables="+g.ServerVariables)
+// packageVersion renders the version in the format of the package ecosystem of the language
+func packageVersion(v *version.Version, language string) string {
+	switch language {
+	case domain.CSharp:
+		return v.NuGet()
+	case domain.Java:
+		return v.Maven()
+	case domain.Python:
+		return v.PEP440()
+	case domain.Typescript, domain.Javascript, domain.Angular:
+		return v.NPM()
+	case domain.Rust:
+		return v.Cargo()
+	default:
+		return v.String()
 	}
+}
 
-	// Generate Package
-	err = g.Cmd.ExecuteAndLog("", "npx", args...)
+// SetOutput creates the output directory and sets it as the output of the generator for the given language
+func (g *BaseGenerator) SetOutput(outputDir, language string) (string, error) {
+	_, err := g.FileIO.MkdirAll(outputDir, 0755)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate package")
+		return "", err
 	}
+
+	generator, ok := g.Cfg.GeneratorCLI.Generators[language]
+	if !ok {
+		return "", errors.New("generator configuration not found for language: " + language)
+	}
+	generator.Output = outputDir
 	return outputDir, nil
 }
+
+// ServerVariablesArgs returns the openapi-generator arguments for the server variables, if any
+func (g *BaseGenerator) ServerVariablesArgs() []string {
+	if g.ServerVariables == "" {
+		return nil
+	}
+	return []string{"--server-variables=" + g.ServerVariables}
+}
+
+// logEffectiveConfig logs the config passed to openapi-generator after any overrides and dynamic variables have been
+// applied
+func (g *BaseGenerator) logEffectiveConfig(language string) {
+	data, err := utils.MarshalJSON(g.Cfg)
+	if err != nil {
+		log.Warn().Msgf("Failed to marshal effective %s config: %s", language, err.Error())
+		return
+	}
+	log.Info().Msgf("%sEffective openapi-generator config for %s:%s\n%s", utils.Cyan, language, utils.Reset, data)
+}
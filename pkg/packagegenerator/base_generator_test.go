@@ -0,0 +1,45 @@
+//go:build unit
+
+package packagegenerator_test
+
+import (
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBaseGenerator_PackageVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		language        string
+		version         string
+		expectedVersion string
+	}{
+		{name: "python renders PEP 440", language: domain.Python, version: "v1.4.0-SNAPSHOT", expectedVersion: "1.4.0.dev0"},
+		{name: "python preview", language: domain.Python, version: "0.0.0-PR-123-4-SNAPSHOT", expectedVersion: "0.0.0rc123.dev4"},
+		{name: "csharp renders NuGet", language: domain.CSharp, version: "v1.4.0-SNAPSHOT", expectedVersion: "1.4.0-SNAPSHOT"},
+		{name: "java renders Maven", language: domain.Java, version: "v1.4.0-SNAPSHOT", expectedVersion: "1.4.0-SNAPSHOT"},
+		{name: "typescript renders npm", language: domain.Typescript, version: "v1.2.3+build.5", expectedVersion: "1.2.3"},
+		{name: "javascript renders npm", language: domain.Javascript, version: "v1.2.3", expectedVersion: "1.2.3"},
+		{name: "angular renders npm", language: domain.Angular, version: "1.2", expectedVersion: "1.2.0"},
+		{name: "rust renders Cargo", language: domain.Rust, version: "v1.2.3", expectedVersion: "1.2.3"},
+		{name: "invalid versions are set as given", language: domain.Python, version: "latest", expectedVersion: "latest"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), tt.language, nil)
+			require.NoError(t, err)
+
+			_, err = packagegenerator.NewBaseGenerator(tt.version, "TestService", "owner", "repo", "token", "user", "/tmp/spec.json", "Client", "", cfg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedVersion, cfg.GeneratorCLI.Generators[tt.language].AdditionalProperties["packageVersion"])
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package packagegenerator
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// MergeConfigs merges the resolved configs of the given generators into one config per generator-cli version, as a
+// single openapi-generator-cli invocation can only run one version. Generators are keyed by language.
+func MergeConfigs(generators map[string]BatchGenerator) (map[string]*openapitools.Config, error) {
+	languages := make([]string, 0, len(generators))
+	for language := range generators {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	merged := make(map[string]*openapitools.Config)
+	for _, language := range languages {
+		cfg := generators[language].ResolveConfig()
+		generator, ok := cfg.GeneratorCLI.Generators[language]
+		if !ok {
+			return nil, errors.New("generator configuration not found for language: " + language)
+		}
+
+		version := cfg.GeneratorCLI.Version
+		if _, ok = merged[version]; !ok {
+			batchCfg := *cfg
+			batchCfg.GeneratorCLI.Generators = make(map[string]*openapitools.Generator)
+			merged[version] = &batchCfg
+		}
+		merged[version].GeneratorCLI.Generators[language] = generator
+	}
+	return merged, nil
+}
+
+// GenerateBatch runs openapi-generator for all the given configs, one invocation per config. The generators must
+// already have been prepared so that their outputs are set.
+func GenerateBatch(openAPIGenerator domain.OpenAPIGenerator, fileIO domain.FileIO, configs map[string]*openapitools.Config, extraArgs ...string) error {
+	versions := make([]string, 0, len(configs))
+	for version := range configs {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	for _, version := range versions {
+		cfg := configs[version]
+		keys := make([]string, 0, len(cfg.GeneratorCLI.Generators))
+		for key := range cfg.GeneratorCLI.Generators {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		data, err := utils.MarshalJSON(cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal batch config")
+		}
+		log.Info().Msgf("%sEffective openapi-generator %s config for %v:%s\n%s", utils.Cyan, version, keys, utils.Reset, data)
+
+		cfgPath, err := cfg.WriteToCurrentWorkingDirectory()
+		if err != nil {
+			return err
+		}
+		err = openAPIGenerator.Generate(cfgPath, keys, extraArgs...)
+		fileIO.DeferRemove(cfgPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate packages for %v", keys)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+package packagegenerator_test
+
+import (
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/angular"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/csharp"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/java"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type generateCall struct {
+	generatorKeys []string
+	cfg           *openapitools.Config
+	extraArgs     []string
+}
+
+// recordingGenerator records the configs openapi-generator would be run with
+type recordingGenerator struct {
+	calls []generateCall
+}
+
+func (r *recordingGenerator) Generate(configPath string, generatorKeys []string, extraArgs ...string) error {
+	cfg, err := openapitools.ReadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	r.calls = append(r.calls, generateCall{generatorKeys: generatorKeys, cfg: cfg, extraArgs: extraArgs})
+	return nil
+}
+
+func newBaseGenerator(t *testing.T, language string) *packagegenerator.BaseGenerator {
+	cfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), language, nil)
+	require.NoError(t, err)
+	base, err := packagegenerator.NewBaseGenerator("1.0.0", "TestService", "owner", "repo", "token", "user", "/tmp/spec.json", "Client", "", cfg)
+	require.NoError(t, err)
+	return base
+}
+
+func TestGenerateBatch(t *testing.T) {
+	t.Chdir(t.TempDir())
+	outputDir := t.TempDir()
+
+	generators := map[string]packagegenerator.BatchGenerator{
+		domain.Java:    java.NewGenerator(newBaseGenerator(t, domain.Java)),
+		domain.Angular: angular.NewGenerator(newBaseGenerator(t, domain.Angular)),
+		domain.CSharp:  csharp.NewGenerator(newBaseGenerator(t, domain.CSharp)),
+	}
+	generatedDirs := make(map[string]string)
+	for language, generator := range generators {
+		dir, err := generator.PrepareGeneration(outputDir + "/" + language)
+		require.NoError(t, err)
+		generatedDirs[language] = dir
+	}
+
+	configs, err := packagegenerator.MergeConfigs(generators)
+	require.NoError(t, err)
+	require.Len(t, configs, 2, "java and angular share a generator version, csharp does not")
+
+	recorder := &recordingGenerator{}
+	err = packagegenerator.GenerateBatch(recorder, file.NewFileIO(), configs, "--server-variables=a=b")
+	require.NoError(t, err)
+	require.Len(t, recorder.calls, 2)
+
+	csharpCall, sharedCall := recorder.calls[0], recorder.calls[1]
+	assert.Equal(t, []string{domain.CSharp}, csharpCall.generatorKeys)
+	assert.Equal(t, "7.1.0", csharpCall.cfg.GeneratorCLI.Version)
+	assert.Equal(t, []string{domain.Angular, domain.Java}, sharedCall.generatorKeys)
+	assert.Equal(t, "7.15.0", sharedCall.cfg.GeneratorCLI.Version)
+	assert.Equal(t, []string{"--server-variables=a=b"}, sharedCall.extraArgs)
+
+	for _, call := range recorder.calls {
+		for key, generator := range call.cfg.GeneratorCLI.Generators {
+			assert.Equal(t, generatedDirs[key], generator.Output, "output for %s", key)
+			assert.Equal(t, "1.0.0", generator.AdditionalProperties["packageVersion"])
+		}
+	}
+	// The language specific variables are set when preparing
+	assert.Equal(t, "Mqube.TestService.Client", csharpCall.cfg.GeneratorCLI.Generators[domain.CSharp].AdditionalProperties["packageName"])
+}
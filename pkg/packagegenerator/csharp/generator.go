@@ -6,11 +6,12 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 )
 
 const (
-	packagingFilesDir = "/templates/csharp"
+	packagingFilesDir = "csharp"
 )
 
 type Generator struct {
@@ -24,22 +25,46 @@ func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 }
 
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
-	g.setDynamicConfigVariables()
+	generatedDir, err := g.PrepareGeneration(outputDir)
+	if err != nil {
+		return "", err
+	}
 
-	packageDir, err := g.BaseGenerator.GeneratePackage(filepath.Join(outputDir, g.GetPackageName()), domain.CSharp)
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.CSharp)
 	if err != nil {
 		return "", err
 	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
+
+// PrepareGeneration sets the csharp specific config variables and the output directory
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	g.setDynamicConfigVariables()
+	return g.SetOutput(filepath.Join(outputDir, g.GetPackageName()), domain.CSharp)
+}
 
-	if err = g.FileIO.TemplateFilesInDir(packagingFilesDir, packageDir, g); err != nil {
+// CompleteGeneration templates the packaging files into the generated package and packs the solution
+func (g *Generator) CompleteGeneration(_, generatedDir string) (string, error) {
+	if err := g.FileIO.TemplateFilesInDir(g.Templates, packagingFilesDir, generatedDir, g); err != nil {
 		return "", err
 	}
 
-	err = g.Cmd.ExecuteAndLog(packageDir, "dotnet", "pack", "-c", "Release", fmt.Sprintf("-p:VERSION=%s", g.Version))
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return "", err
+	}
+
+	err = g.Cmd.ExecuteAndLog(generatedDir, "dotnet", "pack", "-c", "Release", fmt.Sprintf("-p:VERSION=%s", v.NuGet()))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to pack solution")
 	}
-	return packageDir, nil
+	return generatedDir, nil
+}
+
+// ResolveConfig returns the openapi-generator config with the csharp specific variables set
+func (g *Generator) ResolveConfig() *openapitools.Config {
+	g.setDynamicConfigVariables()
+	return g.Cfg
 }
 
 func (g *Generator) setDynamicConfigVariables() {
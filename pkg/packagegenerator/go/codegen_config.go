@@ -0,0 +1,67 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// codegenConfigKey is the environment variable for the path to a per-service oapi-codegen config
+const codegenConfigKey = "GO_CODEGEN_CONFIG"
+
+// codegenConfigFile is the oapi-codegen config file format, as read by the oapi-codegen CLI
+type codegenConfigFile struct {
+	codegen.Configuration `yaml:",inline"`
+	OutputFile            string `yaml:"output,omitempty"`
+}
+
+// LoadCodegenConfig reads an oapi-codegen config file. Unknown fields are an error so that typos aren't silently
+// ignored.
+func LoadCodegenConfig(path string) (*codegen.Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read oapi-codegen config")
+	}
+
+	var cfg codegenConfigFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal oapi-codegen config")
+	}
+
+	if cfg.PackageName != "" || cfg.OutputFile != "" || cfg.Generate != (codegen.GenerateOptions{}) {
+		log.Warn().Msgf("The package, output and generate options of %s are set by the generator and will be ignored", path)
+	}
+	log.Info().Msgf("%sUsing oapi-codegen config from %s%s", utils.Cyan, path, utils.Reset)
+	return &cfg.Configuration, nil
+}
+
+// codegenConfiguration returns the oapi-codegen configuration for a package, merging in the per-service config if
+// there is one. The package name and what to generate are always set by the generator.
+func (g *Generator) codegenConfiguration(packageName string, generate codegen.GenerateOptions, additionalImports ...codegen.AdditionalImport) (codegen.Configuration, error) {
+	var config codegen.Configuration
+	if g.CodegenConfig != nil {
+		config = *g.CodegenConfig
+		config.ImportMapping = maps.Clone(config.ImportMapping)
+		config.AdditionalImports = slices.Clone(config.AdditionalImports)
+	}
+	config.PackageName = packageName
+	config.Generate = generate
+	config.AdditionalImports = append(config.AdditionalImports, additionalImports...)
+
+	err := config.Validate()
+	if err != nil {
+		return config, errors.Wrap(err, "invalid oapi-codegen configuration")
+	}
+	return config, nil
+}
@@ -0,0 +1,191 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+var (
+	// operationSuffixRegex matches the suffixes of the declarations generated for an operation after its name, e.g.
+	// Params, ParamsKind, JSONBody, JSONRequestBody, WithBody or WithResponse
+	operationSuffixRegex = regexp.MustCompile(`^(Response|Params\w*|[A-Za-z0-9]*Body|With\w*)$`)
+	// requestSuffixRegex matches the suffixes of the request builders of an operation after New<Op>Request, e.g.
+	// WithBody or WithFormdataBody
+	requestSuffixRegex = regexp.MustCompile(`^With\w*Body$`)
+)
+
+// specElement is a schema or operation in the spec and the Go name oapi-codegen generates for it
+type specElement struct {
+	goName      string
+	description string
+	// operation is true for operations and false for schemas
+	operation bool
+	// tag is the first tag of an operation, if it has any
+	tag string
+	// propertyTypes are the names of the types generated for the inline properties of a schema, e.g. PetKind
+	propertyTypes []string
+}
+
+// matches returns whether a declaration of the given name is generated from the element
+func (e specElement) matches(name string) bool {
+	if !e.operation {
+		return name == e.goName || strings.HasPrefix(name, e.goName+"_") || slices.Contains(e.propertyTypes, name)
+	}
+	if rest, ok := strings.CutPrefix(name, "New"+e.goName+"Request"); ok {
+		return rest == "" || requestSuffixRegex.MatchString(rest)
+	}
+	if rest, ok := strings.CutPrefix(name, e.goName); ok {
+		return rest == "" || operationSuffixRegex.MatchString(rest)
+	}
+	return name == "Parse"+e.goName+"Response"
+}
+
+// specElements returns the schemas and operations in the spec, longest Go name first so the most specific match wins
+func specElements(swagger *openapi3.T) []specElement {
+	var elements []specElement
+	if swagger == nil {
+		return elements
+	}
+	if swagger.Components != nil {
+		for name, schema := range swagger.Components.Schemas {
+			goName := codegen.SchemaNameToTypeName(name)
+			elements = append(elements, specElement{
+				goName:        goName,
+				description:   "#/components/schemas/" + name,
+				propertyTypes: propertyTypeNames(goName, schema),
+			})
+		}
+	}
+	if swagger.Paths != nil {
+		for path, item := range swagger.Paths.Map() {
+			for method, op := range item.Operations() {
+				if op.OperationID == "" {
+					continue
+				}
+				element := specElement{
+					goName:      codegen.ToCamelCase(op.OperationID),
+					description: fmt.Sprintf("operation %s (%s %s)", op.OperationID, method, path),
+					operation:   true,
+				}
+				if len(op.Tags) > 0 {
+					element.tag = op.Tags[0]
+				}
+				elements = append(elements, element)
+			}
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		if len(elements[i].goName) != len(elements[j].goName) {
+			return len(elements[i].goName) > len(elements[j].goName)
+		}
+		return elements[i].description < elements[j].description
+	})
+	return elements
+}
+
+// propertyTypeNames returns the names oapi-codegen gives the types of a schema's inline properties, the schema name
+// followed by the property name, recursively
+func propertyTypeNames(prefix string, schema *openapi3.SchemaRef) []string {
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+	var names []string
+	for property, propertySchema := range schema.Value.Properties {
+		if propertySchema == nil || propertySchema.Ref != "" {
+			continue
+		}
+		name := prefix + codegen.ToCamelCase(property)
+		names = append(names, name)
+		names = append(names, propertyTypeNames(name, propertySchema)...)
+	}
+	return names
+}
+
+// matchSpecElement returns the spec element a declaration was generated from. Schemas are generated as types of the
+// same name, operations as functions and types named after the operation, e.g. NewGetPetRequest or GetPetResponse.
+// Methods are matched by their receiver, except for the methods of the client, which are matched by their name.
+func matchSpecElement(declaration string, elements []specElement) (specElement, bool) {
+	if declaration == "" {
+		return specElement{}, false
+	}
+	name := declaration
+	if receiver, method, ok := strings.Cut(declaration, "."); ok {
+		name = receiver
+		if receiver == "Client" || receiver == "ClientWithResponses" {
+			name = method
+		}
+	}
+	for _, element := range elements {
+		if element.goName != "" && element.matches(name) {
+			return element, true
+		}
+	}
+	return specElement{}, false
+}
+
+// declarationName returns the name of a top level declaration, Type.Method for methods
+func declarationName(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			return receiverName(d.Recv.List[0].Type) + "." + d.Name.Name
+		}
+		return d.Name.Name
+	case *ast.GenDecl:
+		if len(d.Specs) > 0 {
+			return specName(d.Specs[0])
+		}
+	}
+	return ""
+}
+
+func specName(spec ast.Spec) string {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return s.Name.Name
+	case *ast.ValueSpec:
+		if len(s.Names) > 0 {
+			return s.Names[0].Name
+		}
+	}
+	return ""
+}
+
+// enclosingDeclaration returns the name of the top level function, method or type containing the line
+func enclosingDeclaration(fset *token.FileSet, file *ast.File, line int) string {
+	for _, decl := range file.Decls {
+		if fset.Position(decl.Pos()).Line > line || fset.Position(decl.End()).Line < line {
+			continue
+		}
+		if d, ok := decl.(*ast.GenDecl); ok {
+			for _, spec := range d.Specs {
+				if fset.Position(spec.Pos()).Line <= line && fset.Position(spec.End()).Line >= line {
+					return specName(spec)
+				}
+			}
+		}
+		return declarationName(decl)
+	}
+	return ""
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
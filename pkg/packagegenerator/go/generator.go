@@ -10,9 +10,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/getkin/kin-openapi/openapi3"
 	gh "github.com/google/go-github/v47/github"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
@@ -22,6 +23,7 @@ import (
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/git"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/scmClient/github"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/specconvert"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
 )
 
@@ -35,45 +37,148 @@ type Generator struct {
 	*packagegenerator.BaseGenerator
 	Git domain.Gitter
 	Scm domain.ScmClient
+
+	// Server is the framework to generate server interfaces for in the server subpackage, none are generated if empty
+	Server string
+	// StrictServer generates the strict server wrapper around the server interface
+	StrictServer bool
+	// SplitFiles splits the generated client into models, client and per-tag files instead of a single file
+	SplitFiles bool
+	// ClientHelpers generates auth options for the security schemes and transport options alongside the client
+	ClientHelpers bool
+	// Mocks is the style of mocks to generate for the client and server interfaces, mockery, gomock or none
+	Mocks string
+	// CodegenConfig is the per-service oapi-codegen config merged into the generated configuration, if any
+	CodegenConfig *codegen.Configuration
+	// CodegenConfigPath is the path the per-service oapi-codegen config is read from
+	CodegenConfigPath string
+	// ReleaseBranch is the branch to commit and tag packages on directly, instead of opening a pull request
+	ReleaseBranch string
+	// TagTimeout is how long to wait for the pull request to merge before tagging it, it isn't tagged if zero
+	TagTimeout time.Duration
+	// TagPollInterval is how often to check whether the pull request has merged
+	TagPollInterval time.Duration
+
+	// previewMajor is the latest major version of the package in the repository, which previews are generated for
+	previewMajor uint64
 }
 
 func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 	return &Generator{
-		BaseGenerator: baseGenerator,
-		Git:           git.NewClient(),
-		Scm:           github.NewClient(baseGenerator.RepoOwner, PushRepositoryName, baseGenerator.GitToken),
+		BaseGenerator:     baseGenerator,
+		Git:               git.NewClient(),
+		Scm:               github.NewClient(baseGenerator.RepoOwner, PushRepositoryName, baseGenerator.GitToken),
+		Server:            os.Getenv(serverKey),
+		StrictServer:      os.Getenv(strictServerKey) == "true",
+		SplitFiles:        splitFilesFromEnvironment(),
+		ClientHelpers:     clientHelpersFromEnvironment(),
+		Mocks:             mockStyleFromEnvironment(),
+		CodegenConfigPath: os.Getenv(codegenConfigKey),
+		ReleaseBranch:     os.Getenv(releaseBranchKey),
+		TagTimeout:        tagTimeoutFromEnvironment(),
+		TagPollInterval:   defaultTagPollInterval,
+	}
+}
+
+// UsesOpenAPIGenerator returns false, as go packages are generated with oapi-codegen
+func (g *Generator) UsesOpenAPIGenerator() bool {
+	return false
+}
+
+// GenerationOptions returns the server, file, helper, mock and oapi-codegen options, which change the generated package
+func (g *Generator) GenerationOptions() []string {
+	options := []string{
+		"server=" + g.serverDescription(),
+		"split-files=" + strconv.FormatBool(g.SplitFiles),
+		"client-helpers=" + strconv.FormatBool(g.ClientHelpers),
+		"mocks=" + g.Mocks,
+	}
+	if g.CodegenConfigPath != "" {
+		// The config is validated when generating, here we only need its content to detect changes
+		data, _ := os.ReadFile(g.CodegenConfigPath)
+		options = append(options, "codegen-config="+string(data))
 	}
+	return options
 }
 
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
+	if g.Server != "" {
+		if _, err := g.serverGenerateOptions(); err != nil {
+			return "", err
+		}
+	}
+	if err := g.validateMocks(); err != nil {
+		return "", err
+	}
+	if g.CodegenConfigPath != "" && g.CodegenConfig == nil {
+		codegenConfig, err := LoadCodegenConfig(g.CodegenConfigPath)
+		if err != nil {
+			return "", err
+		}
+		g.CodegenConfig = codegenConfig
+	}
+
 	repoDir, err := g.Git.Clone(outputDir, PushRepositoryURL)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to clone pipeline schemas")
 	}
 
-	branchName := fmt.Sprintf("update/%s/%s", g.GetPackageName(), g.Version)
-	err = g.Git.CheckoutBranch(repoDir, branchName)
+	err = g.checkoutBranch(repoDir)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to checkout branch")
 	}
 
-	packageDir := filepath.Join(repoDir, g.GetPackageName())
+	if g.isPreview() {
+		g.previewMajor, err = latestMajorVersion(filepath.Join(repoDir, g.GetPackageName()))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to find the latest major version")
+		}
+	}
+
+	packageDir, err := g.packageDir(repoDir)
+	if err != nil {
+		return "", err
+	}
 
 	err = g.createFreshDir(packageDir)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create fresh directory")
 	}
 
-	code, err := g.generateCode()
+	swagger, err := g.loadSpec()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load spec")
+	}
+
+	code, err := g.generateCode(swagger)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to generate code")
 	}
 
-	// write code to file
-	codeFile := filepath.Join(packageDir, "client_generated.go")
-	err = g.FileIO.Write(codeFile, []byte(code), 0700)
+	files, err := g.clientFiles(code, swagger)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to write code to file")
+		return "", errors.Wrap(err, "failed to split code into files")
+	}
+	for name, data := range files {
+		err = g.FileIO.Write(filepath.Join(packageDir, name), data, 0700)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to write code to file")
+		}
+	}
+
+	if g.ClientHelpers {
+		err = g.generateHelpers(swagger, packageDir, files)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate client helpers")
+		}
+	}
+
+	if g.Server != "" {
+		log.Info().Msgf("Generating %s server interfaces", g.serverDescription())
+		err = g.generateServer(swagger, packageDir)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate server")
+		}
 	}
 
 	// Openapitools sets the module name to the REPO_NAME, we need it to be the PushRepositoryName
@@ -105,6 +210,12 @@ func (g *Generator) GeneratePackage(outputDir string) (string, error) {
 		return "", errors.Wrap(err, "failed to create package version file")
 	}
 
+	// Make sure the package compiles before it's committed
+	err = g.verifyPackage(packageDir, swagger)
+	if err != nil {
+		return "", errors.Wrap(err, "generated package failed verification")
+	}
+
 	err = g.Git.AddFiles(repoDir, packageDir)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to add files to Git")
@@ -124,12 +235,12 @@ func (g *Generator) GetPackageName() string {
 func (g *Generator) getMajorVersionString() (string, error) {
 	var versionString string
 
-	semversion, err := semver.NewVersion(g.Version)
+	v, err := g.moduleVersion()
 	if err != nil {
-		return versionString, errors.Wrap(err, "failed to parse version")
+		return versionString, err
 	}
 
-	majorVersion := semversion.Major()
+	majorVersion := v.Major()
 	if majorVersion > 1 {
 		versionString = fmt.Sprintf("/v%d", majorVersion)
 	}
@@ -138,24 +249,38 @@ func (g *Generator) getMajorVersionString() (string, error) {
 }
 
 func (g *Generator) goModInit(dir string) error {
-	versionString, err := g.getMajorVersionString()
+	newModuleName, err := g.moduleName()
 	if err != nil {
-		return errors.Wrap(err, "failed to get major version string")
+		return err
 	}
-
-	newModuleName := fmt.Sprintf("github.com/spring-financial-group/%s/%s%s", PushRepositoryName, g.GetPackageName(), versionString)
 	return g.Cmd.ExecuteAndLog(dir, "go", "mod", "init", newModuleName)
 }
 
+// moduleName returns the module path of the package in the PushRepositoryName repository
+func (g *Generator) moduleName() (string, error) {
+	versionString, err := g.getMajorVersionString()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get major version string")
+	}
+	return fmt.Sprintf("github.com/spring-financial-group/%s/%s%s", PushRepositoryName, g.GetPackageName(), versionString), nil
+}
+
 func (g *Generator) goModTidy(dir string) error {
 	return g.Cmd.ExecuteAndLog(dir, "go", "mod", "tidy")
 }
 
 func (g *Generator) createPackageVersionFile(packageDir string) error {
-	return g.FileIO.Write(filepath.Join(packageDir, "VERSION"), []byte(g.Version), 0700)
+	return g.FileIO.Write(filepath.Join(packageDir, versionFileName), []byte(g.Version), 0700)
 }
 
 func (g *Generator) PushPackage(packageDir string) error {
+	if g.isPreview() {
+		return g.pushPreview(packageDir)
+	}
+	if g.ReleaseBranch != "" {
+		return g.pushToReleaseBranch(packageDir)
+	}
+
 	currentBranch, err := g.Git.GetCurrentBranch(packageDir)
 	if err != nil {
 		return errors.Wrap(err, "failed to get current branch")
@@ -171,14 +296,19 @@ func (g *Generator) PushPackage(packageDir string) error {
 		return errors.Wrap(err, "failed to get default branch name")
 	}
 
-	err = g.createPullRequest(currentBranch, defaultBranch)
+	pr, err := g.createPullRequest(currentBranch, defaultBranch)
 	if err != nil {
 		return errors.Wrap(err, "failed to create pull request")
 	}
+
+	err = g.tagPullRequest(pr)
+	if err != nil {
+		return errors.Wrap(err, "failed to tag package")
+	}
 	return nil
 }
 
-func (g *Generator) createPullRequest(currentBranch, defaultBranch string) error {
+func (g *Generator) createPullRequest(currentBranch, defaultBranch string) (*gh.PullRequest, error) {
 	pr, err := g.Scm.CreatePullRequest(
 		context.Background(),
 		&gh.NewPullRequest{
@@ -190,25 +320,34 @@ func (g *Generator) createPullRequest(currentBranch, defaultBranch string) error
 		},
 	)
 	if err != nil {
-		return errors.Wrap(err, "failed to create pull request")
+		return nil, errors.Wrap(err, "failed to create pull request")
 	}
 
 	// auto-merge labels
 	_, err = g.Scm.AddLabels(context.Background(), []string{updateBotLabel}, pr.GetNumber())
 	if err != nil {
-		return errors.Wrap(err, "failed to add labels pull request")
+		return nil, errors.Wrap(err, "failed to add labels pull request")
 	}
-	return nil
+	return pr, nil
 }
 
 func (g *Generator) createFreshDir(packageDir string) error {
-	// Check if directory exists
-	if _, err := os.Stat(packageDir); err == nil {
-		// Remove entire directory and its contents
-		if err := os.RemoveAll(packageDir); err != nil {
-			return errors.Wrapf(err, "failed to remove existing directory: %s", packageDir)
+	// Remove the contents of the directory if it exists, except the major version subdirectories which are separate
+	// modules
+	entries, err := os.ReadDir(packageDir)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to read existing directory: %s", packageDir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && majorVersionDirRegex.MatchString(entry.Name()) {
+			continue
 		}
-		log.Info().Msgf("Removed existing directory: %s", packageDir)
+		if err := os.RemoveAll(filepath.Join(packageDir, entry.Name())); err != nil {
+			return errors.Wrapf(err, "failed to remove existing contents of directory: %s", packageDir)
+		}
+	}
+	if len(entries) > 0 {
+		log.Info().Msgf("Removed existing contents of directory: %s", packageDir)
 	}
 
 	// Create a fresh directory
@@ -220,13 +359,13 @@ func (g *Generator) createFreshDir(packageDir string) error {
 	return nil
 }
 
-func (g *Generator) generateCode() (string, error) {
+func (g *Generator) loadSpec() (*openapi3.T, error) {
 	// Read file
 	var swaggerData []byte
 
 	swaggerData, err := os.ReadFile(g.SpecPath)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// replace all `Response"` occurences with `ResponseDto"` to avoid compilation errors
@@ -237,31 +376,38 @@ func (g *Generator) generateCode() (string, error) {
 	if g.isSwaggerV2(swaggerData) {
 		swaggerData, err = g.convertSwaggerV2toV3(swaggerData)
 		if err != nil {
-			return "", errors.Wrap(err, "failed to convert spec")
+			return nil, errors.Wrap(err, "failed to convert spec")
+		}
+	}
+
+	// oapi-codegen doesn't support 3.1 yet, so it's converted to 3.0 with anything that can't be converted reported
+	if specconvert.IsV31(swaggerData) {
+		log.Info().Msgf("%sConverting OpenAPI 3.1 spec to %s%s", utils.Cyan, specconvert.TargetVersion, utils.Reset)
+		var issues []specconvert.Issue
+		swaggerData, issues, err = specconvert.DowngradeV31(swaggerData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert OpenAPI 3.1 spec")
+		}
+		for _, issue := range issues {
+			log.Warn().Msgf("Couldn't convert %s", issue)
 		}
 	}
 
 	loader := openapi3.NewLoader()
 	swagger, err := loader.LoadFromData(swaggerData)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to load spec")
-	}
-
-	if strings.HasPrefix(swagger.OpenAPI, "3.1.") {
-		log.Warn().Msg("You are using an OpenAPI 3.1.x specification, which is not yet supported by oapi-codegen. Some functionality may not be available. Until oapi-codegen supports OpenAPI 3.1, it is recommended to downgrade your spec to 3.0.x")
+		return nil, errors.Wrap(err, "failed to load spec")
 	}
+	return swagger, nil
+}
 
-	swagger, err = loader.LoadFromData(swaggerData)
+func (g *Generator) generateCode(swagger *openapi3.T) (string, error) {
+	config, err := g.codegenConfiguration(g.GetPackageName(), codegen.GenerateOptions{
+		Client: true,
+		Models: true,
+	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to load spec")
-	}
-
-	config := codegen.Configuration{
-		PackageName: g.GetPackageName(),
-		Generate: codegen.GenerateOptions{
-			Client: true,
-			Models: true,
-		},
+		return "", err
 	}
 
 	code, err := codegen.Generate(swagger, config)
@@ -272,11 +418,6 @@ func (g *Generator) generateCode() (string, error) {
 	return code, nil
 }
 
-func (g *Generator) generateMocks(dir string) error {
-	err := g.Cmd.ExecuteAndLog(dir, "mockery", "--all", "--inpackage-suffix", "--inpackage", "--case", "snake")
-	return err
-}
-
 func (g *Generator) convertSwaggerV2toV3(data []byte) ([]byte, error) {
 	var response []byte
 	// Unmarshal into a map
@@ -0,0 +1,687 @@
+//go:build unit
+
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go_test
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/mockgen"
+	_go "github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/go"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/packagegeneratortest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "paths": {
+    "/internal/health": {
+      "get": {
+        "operationId": "getHealth",
+        "tags": ["internal"],
+        "responses": {"200": {"description": "OK"}}
+      }
+    },
+    "/pets/{id}": {
+      "get": {
+        "operationId": "getPet",
+        "tags": ["pets"],
+        "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string"}}}
+    }
+  }
+}`
+
+// newGenerator returns a generator that generates into a fake clone of the packages repository
+func newGenerator(t *testing.T, version string) (*_go.Generator, string) {
+	specPath := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(testSpec), 0600))
+
+	base, _ := packagegeneratortest.NewBaseGenerator(t, domain.Go, packagegeneratortest.Options{Version: version, SpecPath: specPath})
+
+	repoDir := t.TempDir()
+	gitter := mocks.NewGitter(t)
+	gitter.On("Clone", mock.Anything, _go.PushRepositoryURL).Return(repoDir, nil).Maybe()
+	gitter.On("CheckoutBranch", repoDir, mock.Anything).Return(nil).Maybe()
+	gitter.On("AddFiles", repoDir, mock.Anything).Return(nil).Maybe()
+	gitter.On("Commit", repoDir, mock.Anything).Return(nil).Maybe()
+
+	base.Cmd = newCommandRunner(t, "", nil)
+
+	g := _go.NewGenerator(base)
+	g.Git = gitter
+	return g, repoDir
+}
+
+// newCommandRunner returns a command runner where go vet passes and go build returns the given output and error
+func newCommandRunner(t *testing.T, buildOutput any, buildErr error) *mocks.CommandRunner {
+	cmd := mocks.NewCommandRunner(t)
+	cmd.On("ExecuteAndLog", mock.Anything, "go", "mod", "init", mock.Anything).Return(nil).Maybe()
+	cmd.On("ExecuteAndLog", mock.Anything, "go", "mod", "tidy").Return(nil).Maybe()
+	cmd.On("Execute", mock.Anything, "go", "build", "./...").Return(buildOutput, buildErr).Maybe()
+	cmd.On("Execute", mock.Anything, "go", "vet", "./...").Return("", nil).Maybe()
+	return cmd
+}
+
+func TestGenerator_GeneratePackage_Server(t *testing.T) {
+	testCases := []struct {
+		name             string
+		server           string
+		strict           bool
+		version          string
+		expectedDir      string
+		expectedContains []string
+	}{
+		{
+			name:        "Chi server",
+			server:      _go.ServerChi,
+			version:     "1.0.0",
+			expectedDir: "testservice",
+			expectedContains: []string{
+				"package server",
+				`. "github.com/spring-financial-group/mqube-go-packages/testservice"`,
+				"type ServerInterface interface",
+				"GetPet(w http.ResponseWriter, r *http.Request, id string)",
+				"github.com/go-chi/chi/v5",
+				"func GetSwagger()",
+			},
+		},
+		{
+			name:        "Strict std http server on a major version",
+			server:      _go.ServerStdHTTP,
+			strict:      true,
+			version:     "2.1.0",
+			expectedDir: "testservice/v2",
+			expectedContains: []string{
+				`. "github.com/spring-financial-group/mqube-go-packages/testservice/v2"`,
+				"type StrictServerInterface interface",
+				"GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error)",
+				"GetPet200JSONResponse Pet",
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, repoDir := newGenerator(t, tt.version)
+			g.Server = tt.server
+			g.StrictServer = tt.strict
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(repoDir, tt.expectedDir), packageDir)
+
+			client, err := os.ReadFile(filepath.Join(packageDir, "client_generated.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(client), "type Pet struct")
+
+			server, err := os.ReadFile(filepath.Join(packageDir, "server", "server_generated.go"))
+			require.NoError(t, err)
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, string(server), expected)
+			}
+			assert.NotContains(t, string(server), "type Pet struct", "models are imported from the client package")
+		})
+	}
+
+	t.Run("No server by default", func(t *testing.T) {
+		g, _ := newGenerator(t, "1.0.0")
+
+		packageDir, err := g.GeneratePackage(t.TempDir())
+		require.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(packageDir, "server"))
+	})
+
+	t.Run("Unsupported server", func(t *testing.T) {
+		g, _ := newGenerator(t, "1.0.0")
+		g.Server = "fiber"
+
+		_, err := g.GeneratePackage(t.TempDir())
+		assert.ErrorContains(t, err, "unsupported GO_SERVER")
+	})
+}
+
+func TestGenerator_GeneratePackage_CodegenConfig(t *testing.T) {
+	testCases := []struct {
+		name                string
+		config              string
+		expectedContains    []string
+		expectedNotContains []string
+		expectedErr         string
+	}{
+		{
+			name: "Output options and import mapping are merged",
+			config: `
+package: ignored
+output-options:
+  exclude-tags:
+    - internal
+import-mapping:
+  ./common.yaml: github.com/spring-financial-group/mqube-go-packages/common
+`,
+			expectedContains:    []string{"package testservice", "GetPet("},
+			expectedNotContains: []string{"GetHealth("},
+		},
+		{
+			name:        "Unknown fields are an error",
+			config:      "output-option:\n  skip-prune: true\n",
+			expectedErr: "failed to unmarshal oapi-codegen config",
+		},
+		{
+			name: "Invalid options are an error",
+			config: `
+output-options:
+  additional-initialisms:
+    - API
+`,
+			expectedErr: "invalid oapi-codegen configuration",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "oapi-codegen.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.config), 0600))
+
+			g, _ := newGenerator(t, "1.0.0")
+			g.CodegenConfigPath = configPath
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			client, err := os.ReadFile(filepath.Join(packageDir, "client_generated.go"))
+			require.NoError(t, err)
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, string(client), expected)
+			}
+			for _, notExpected := range tt.expectedNotContains {
+				assert.NotContains(t, string(client), notExpected)
+			}
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_Mocks(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mocks            string
+		server           string
+		expectedFiles    []string
+		expectedContains []string
+		expectedErr      string
+	}{
+		{
+			name:  "Mockery mocks by default",
+			mocks: "mockery",
+			expectedFiles: []string{
+				"client_interface_mock.go",
+				"client_with_responses_interface_mock.go",
+				"http_request_doer_mock.go",
+			},
+			expectedContains: []string{"type MockClientInterface struct", "mock.Mock", "func NewMockClientInterface("},
+		},
+		{
+			name:          "GoMock mocks for the client and server",
+			mocks:         "gomock",
+			server:        _go.ServerChi,
+			expectedFiles: []string{"client_interface_mock.go", "server/server_interface_mock.go"},
+			expectedContains: []string{
+				"func NewMockClientInterface(ctrl *gomock.Controller) *MockClientInterface",
+				"func (m *MockClientInterface) EXPECT() *MockClientInterfaceMockRecorder",
+			},
+		},
+		{
+			name:  "No mocks",
+			mocks: _go.MocksNone,
+		},
+		{
+			name:        "Unsupported style",
+			mocks:       "moq",
+			expectedErr: "invalid GO_MOCKS",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newGenerator(t, "1.0.0")
+			g.Mocks = tt.mocks
+			g.Server = tt.server
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, file := range tt.expectedFiles {
+				assert.FileExists(t, filepath.Join(packageDir, file))
+			}
+			if len(tt.expectedFiles) == 0 {
+				assert.NoFileExists(t, filepath.Join(packageDir, "client_interface_mock.go"))
+				return
+			}
+
+			clientMock, err := os.ReadFile(filepath.Join(packageDir, "client_interface_mock.go"))
+			require.NoError(t, err)
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, string(clientMock), expected)
+			}
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_Verify(t *testing.T) {
+	// lineOf returns the line number of the first line in the generated client starting with prefix
+	lineOf := func(dir, prefix string) int {
+		data, err := os.ReadFile(filepath.Join(dir, "client_generated.go"))
+		require.NoError(t, err)
+		for i, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, prefix) {
+				return i + 1
+			}
+		}
+		t.Fatalf("%q not found in generated client", prefix)
+		return 0
+	}
+
+	testCases := []struct {
+		name        string
+		output      func(dir string, args ...string) string
+		expectedErr []string
+	}{
+		{
+			name: "Errors are reported against the spec elements",
+			output: func(dir string, _ ...string) string {
+				return fmt.Sprintf("# github.com/spring-financial-group/mqube-go-packages/testservice\n"+
+					"./client_generated.go:%d:2: undefined: Owner\n"+
+					"./client_generated.go:%d:10: declared and not used: x\n",
+					lineOf(dir, "type Pet struct")+1, lineOf(dir, "func NewGetPetRequest(")+1)
+			},
+			expectedErr: []string{
+				"go build ./... failed on the generated package",
+				"undefined: Owner (generated from #/components/schemas/Pet)",
+				"declared and not used: x (generated from operation GetPet (GET /pets/{id}))",
+			},
+		},
+		{
+			name: "Errors outside generated declarations",
+			output: func(string, ...string) string {
+				return "go: updates to go.mod needed; to update it:\n\tgo mod tidy"
+			},
+			expectedErr: []string{"go build ./... failed on the generated package: go: updates to go.mod needed"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newGenerator(t, "1.0.0")
+			g.Mocks = _go.MocksNone
+			g.Cmd = newCommandRunner(t, func(dir, _ string, args ...string) string {
+				return tt.output(dir, args...)
+			}, errors.New("exit status 1"))
+			gitter := mocks.NewGitter(t)
+			gitter.On("Clone", mock.Anything, _go.PushRepositoryURL).Return(t.TempDir(), nil)
+			gitter.On("CheckoutBranch", mock.Anything, mock.Anything).Return(nil)
+			g.Git = gitter
+
+			_, err := g.GeneratePackage(t.TempDir())
+			require.Error(t, err)
+
+			var verificationErr *_go.VerificationError
+			assert.ErrorAs(t, err, &verificationErr)
+			for _, expected := range tt.expectedErr {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_SplitFiles(t *testing.T) {
+	g, _ := newGenerator(t, "1.0.0")
+	g.SplitFiles = true
+
+	packageDir, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(packageDir, "client_generated.go"))
+
+	expectedFiles := map[string]struct {
+		contains    []string
+		notContains []string
+	}{
+		"client_gen.go": {
+			contains:    []string{"// Package testservice provides primitives", "type ClientInterface interface", "func NewClient("},
+			notContains: []string{"type Pet struct", "func NewGetPetRequest("},
+		},
+		"models_gen.go": {
+			contains:    []string{"// Code generated by", "type Pet struct"},
+			notContains: []string{"type ClientInterface interface", `"net/http"`},
+		},
+		"pets_gen.go": {
+			contains:    []string{"func (c *Client) GetPet(", "func NewGetPetRequest(", "type GetPetResponse struct"},
+			notContains: []string{"GetHealth"},
+		},
+		"internal_gen.go": {
+			contains:    []string{"func (c *Client) GetHealth(", "func ParseGetHealthResponse("},
+			notContains: []string{"GetPet"},
+		},
+		"client_interface_mock.go": {
+			contains: []string{"type MockClientInterface struct"},
+		},
+	}
+	for name, expected := range expectedFiles {
+		data, err := os.ReadFile(filepath.Join(packageDir, name))
+		require.NoError(t, err, name)
+
+		_, err = parser.ParseFile(token.NewFileSet(), name, data, parser.AllErrors)
+		assert.NoError(t, err, name)
+		for _, s := range expected.contains {
+			assert.Contains(t, string(data), s, name)
+		}
+		for _, s := range expected.notContains {
+			assert.NotContains(t, string(data), s, name)
+		}
+	}
+}
+
+func TestGenerator_GeneratePackage_SplitFilesSchemaNames(t *testing.T) {
+	// Schemas named like the client's declarations, e.g. ClientInterface, must not move them to the models
+	spec := strings.Replace(testSpec, `"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}`,
+		`"Pet": {"type": "object", "properties": {
+			"kind": {"type": "string", "enum": ["dog", "cat"]},
+			"client": {"$ref": "#/components/schemas/Client"},
+			"interface": {"$ref": "#/components/schemas/Interface"}
+		}},
+		"Client": {"type": "object", "properties": {"name": {"type": "string"}}},
+		"Interface": {"type": "string"}`, 1)
+	g, _ := newGenerator(t, "1.0.0")
+	g.SplitFiles = true
+	g.SpecPath = filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(g.SpecPath, []byte(spec), 0600))
+
+	packageDir, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+
+	client, err := os.ReadFile(filepath.Join(packageDir, "client_gen.go"))
+	require.NoError(t, err)
+	for _, expected := range []string{"type ClientInterface interface", "type ClientWithResponsesInterface interface", "func NewClient(", "type ClientOption func"} {
+		assert.Contains(t, string(client), expected)
+	}
+	models, err := os.ReadFile(filepath.Join(packageDir, "models_gen.go"))
+	require.NoError(t, err)
+	for _, expected := range []string{"type Client struct", "type Interface = string", "type PetKind string"} {
+		assert.Contains(t, string(models), expected)
+	}
+	assert.NotContains(t, string(models), "ClientInterface")
+	assert.FileExists(t, filepath.Join(packageDir, "client_interface_mock.go"))
+}
+
+func TestGenerator_GeneratePackage_OpenAPI31(t *testing.T) {
+	spec := `{
+  "openapi": "3.1.0",
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["kind", "nickname"],
+        "properties": {
+          "kind": {"type": "string", "const": "dog"},
+          "nickname": {"type": ["string", "null"], "examples": ["Rex"]},
+          "owner": {"$ref": "#/components/schemas/Owner", "description": "The pet's owner"}
+        }
+      },
+      "Owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+    }
+  }
+}`
+	g, _ := newGenerator(t, "1.0.0")
+	g.SpecPath = filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(g.SpecPath, []byte(spec), 0600))
+
+	packageDir, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+
+	client, err := os.ReadFile(filepath.Join(packageDir, "client_generated.go"))
+	require.NoError(t, err)
+	assert.Regexp(t, `Dog\s+PetKind = "dog"`, string(client))
+	assert.Regexp(t, `Nickname\s+\*string`, string(client))
+	assert.Regexp(t, `Owner\s+\*Owner`, string(client))
+}
+
+func TestGenerator_GeneratePackage_GoBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping go build test in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("Skipping go build test as go is not installed")
+	}
+	// The generated module is resolved on its own, outside of any workspace this repository is built in
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOFLAGS", "")
+
+	// listPets has a query parameter, so the server interface uses a params type from the dot imported client
+	spec := strings.Replace(testSpec, `"paths": {`, `"paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "tags": ["pets"],
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}
+        }
+      }
+    },`, 1)
+	spec = strings.Replace(spec, `"components": {`, `"components": {"securitySchemes": {"bearerAuth": {"type": "http", "scheme": "bearer"}},`, 1)
+
+	testCases := []struct {
+		name   string
+		server string
+		strict bool
+		mocks  string
+	}{
+		{
+			name:   "Strict chi server with mockery mocks",
+			server: _go.ServerChi,
+			strict: true,
+			mocks:  string(mockgen.Mockery),
+		},
+		{
+			name:   "Chi server with gomock mocks",
+			server: _go.ServerChi,
+			mocks:  string(mockgen.GoMock),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newGenerator(t, "1.0.0")
+			g.Cmd = commandrunner.NewCommandRunner()
+			g.Server, g.StrictServer, g.Mocks = tt.server, tt.strict, tt.mocks
+			g.SplitFiles, g.ClientHelpers = true, true
+			g.SpecPath = filepath.Join(t.TempDir(), "spec.json")
+			require.NoError(t, os.WriteFile(g.SpecPath, []byte(spec), 0600))
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			var verificationErr *_go.VerificationError
+			if err != nil && !errors.As(err, &verificationErr) {
+				t.Skipf("Skipping go build test as the dependencies of the generated module can't be resolved: %s", err)
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(packageDir, "server", "server_interface_mock.go"))
+			assert.FileExists(t, filepath.Join(packageDir, "helpers_generated.go"))
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_ClientHelpers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		securitySchemes     string
+		schemas             string
+		disabled            bool
+		expectedContains    []string
+		expectedNotContains []string
+	}{
+		{
+			name: "Security schemes",
+			securitySchemes: `{
+				"bearerAuth": {"type": "http", "scheme": "bearer"},
+				"basicAuth": {"type": "http", "scheme": "basic"},
+				"api_key": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"tenant": {"type": "apiKey", "in": "query", "name": "tenant"}
+			}`,
+			expectedContains: []string{
+				"type TokenSource interface",
+				"func NewAuthenticatedClient(server string, tokenSource TokenSource, opts ...ClientOption) (*ClientWithResponses, error)",
+				"func WithBasicAuth(username, password string) ClientOption",
+				`func WithApiKey(apiKey string) ClientOption`,
+				`req.Header.Set("X-API-Key", apiKey)`,
+				`query.Set("tenant", apiKey)`,
+				"func WithRetries(policy RetryPolicy) ClientOption",
+				"func WithCorrelationID() ClientOption",
+			},
+		},
+		{
+			name:                "No security schemes",
+			securitySchemes:     `{}`,
+			expectedContains:    []string{"func WithRetries(policy RetryPolicy) ClientOption"},
+			expectedNotContains: []string{"TokenSource", "WithBasicAuth"},
+		},
+		{
+			name: "Names declared by the client",
+			securitySchemes: `{
+				"bearerAuth": {"type": "http", "scheme": "bearer"},
+				"header": {"type": "apiKey", "in": "header", "name": "X-Header"}
+			}`,
+			schemas: `"Pet": {"type": "object", "properties": {
+					"retryPolicy": {"$ref": "#/components/schemas/RetryPolicy"},
+					"tokenSource": {"$ref": "#/components/schemas/TokenSource"}
+				}},
+				"RetryPolicy": {"type": "object", "properties": {"name": {"type": "string"}}},
+				"TokenSource": {"type": "string"}`,
+			expectedContains: []string{
+				"func WithHeader(name, value string) ClientOption",
+				"func WithHeaderAPIKey(apiKey string) ClientOption",
+				`req.Header.Set("X-Header", apiKey)`,
+				"func WithCorrelationID() ClientOption",
+			},
+			expectedNotContains: []string{"TokenSource", "WithBearerToken", "RetryPolicy", "WithRetries", `"time"`},
+		},
+		{
+			name:            "Disabled",
+			securitySchemes: `{"bearerAuth": {"type": "http", "scheme": "bearer"}}`,
+			disabled:        true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := strings.Replace(testSpec, `"components": {`, `"components": {"securitySchemes": `+tt.securitySchemes+`,`, 1)
+			if tt.schemas != "" {
+				spec = strings.Replace(spec, `"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}`, tt.schemas, 1)
+			}
+			g, _ := newGenerator(t, "1.0.0")
+			g.ClientHelpers = !tt.disabled
+			g.SpecPath = filepath.Join(t.TempDir(), "spec.json")
+			require.NoError(t, os.WriteFile(g.SpecPath, []byte(spec), 0600))
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			require.NoError(t, err)
+
+			helpersFile := filepath.Join(packageDir, "helpers_generated.go")
+			if tt.disabled {
+				assert.NoFileExists(t, helpersFile)
+				return
+			}
+			helpers, err := os.ReadFile(helpersFile)
+			require.NoError(t, err)
+
+			_, err = parser.ParseFile(token.NewFileSet(), helpersFile, helpers, parser.AllErrors)
+			assert.NoError(t, err)
+			assert.Contains(t, string(helpers), "package testservice")
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, string(helpers), expected)
+			}
+			for _, notExpected := range tt.expectedNotContains {
+				assert.NotContains(t, string(helpers), notExpected)
+			}
+			assertNoDuplicateDeclarations(t, packageDir)
+		})
+	}
+}
+
+// assertNoDuplicateDeclarations asserts that no package level identifier is declared twice in the package's files
+func assertNoDuplicateDeclarations(t *testing.T, packageDir string) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, packageDir, nil, parser.SkipObjectResolution)
+	require.NoError(t, err)
+	declared := make(map[string]string)
+	for _, pkg := range pkgs {
+		for fileName, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				var names []string
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if decl.Recv == nil {
+						names = append(names, decl.Name.Name)
+					}
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						switch spec := spec.(type) {
+						case *ast.TypeSpec:
+							names = append(names, spec.Name.Name)
+						case *ast.ValueSpec:
+							for _, name := range spec.Names {
+								names = append(names, name.Name)
+							}
+						}
+					}
+				}
+				for _, name := range names {
+					if previous, ok := declared[name]; ok {
+						t.Errorf("%s is declared in %s and %s", name, filepath.Base(previous), filepath.Base(fileName))
+					}
+					declared[name] = fileName
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,410 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// clientHelpersKey is the environment variable to disable generating the client helpers
+const clientHelpersKey = "GO_CLIENT_HELPERS"
+
+const helpersFileName = "helpers_generated.go"
+
+// clientHelpersFromEnvironment returns whether to generate the client helpers, which is the default
+func clientHelpersFromEnvironment() bool {
+	return os.Getenv(clientHelpersKey) != "false"
+}
+
+// apiKeyScheme is an apiKey security scheme that a With<Name> option is generated for
+type apiKeyScheme struct {
+	Scheme    string
+	Name      string
+	ParamName string
+	In        string
+}
+
+type helpersData struct {
+	Package       string
+	Imports       []string
+	BearerSchemes []string
+	BasicSchemes  []string
+	APIKeys       []apiKeyScheme
+	CorrelationID bool
+	Header        bool
+	Retries       bool
+}
+
+// Identifiers declared by each group of helpers, a group is only generated if none of them are declared already
+var (
+	bearerIdentifiers        = []string{"TokenSource", "TokenSourceFunc", "StaticToken", "WithBearerToken", "NewAuthenticatedClient"}
+	basicIdentifiers         = []string{"WithBasicAuth"}
+	correlationIDIdentifiers = []string{"CorrelationIDHeader", "correlationIDContextKey", "ContextWithCorrelationID", "CorrelationIDFromContext", "WithCorrelationID"}
+	headerIdentifiers        = []string{"WithHeader"}
+	retriesIdentifiers       = []string{"RetryPolicy", "DefaultRetryPolicy", "WithRetries", "retryingRequestDoer", "isIdempotentMethod", "isRetryable"}
+)
+
+// generateHelpers writes the auth options for the spec's security schemes and the transport options, e.g. retries and
+// correlation IDs, so that consumers don't each write their own request editors. Helpers whose names are already
+// declared by the generated client files, e.g. for a schema named RetryPolicy, are left out.
+func (g *Generator) generateHelpers(swagger *openapi3.T, packageDir string, files map[string][]byte) error {
+	declared, err := declaredNames(files)
+	if err != nil {
+		return err
+	}
+	// declare claims the identifiers of a group of helpers, if none of them are declared already
+	declare := func(group string, identifiers ...string) bool {
+		for _, identifier := range identifiers {
+			if declared[identifier] {
+				log.Warn().Msgf("Not generating the %s client helpers as %s is already declared in the package", group, identifier)
+				return false
+			}
+		}
+		for _, identifier := range identifiers {
+			declared[identifier] = true
+		}
+		return true
+	}
+
+	data := helpersData{Package: g.GetPackageName()}
+	var bearerSchemes, basicSchemes []string
+	var apiKeys []apiKeyScheme
+	if swagger.Components != nil {
+		names := make([]string, 0, len(swagger.Components.SecuritySchemes))
+		for name := range swagger.Components.SecuritySchemes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			ref := swagger.Components.SecuritySchemes[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			scheme := ref.Value
+			switch {
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"), scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+				bearerSchemes = append(bearerSchemes, name)
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				basicSchemes = append(basicSchemes, name)
+			case scheme.Type == "apiKey":
+				apiKeys = append(apiKeys, apiKeyScheme{
+					Scheme:    name,
+					Name:      codegen.ToCamelCase(name),
+					ParamName: scheme.Name,
+					In:        scheme.In,
+				})
+			}
+		}
+	}
+
+	if len(bearerSchemes) > 0 && declare("bearer auth", bearerIdentifiers...) {
+		data.BearerSchemes = bearerSchemes
+	}
+	if len(basicSchemes) > 0 && declare("basic auth", basicIdentifiers...) {
+		data.BasicSchemes = basicSchemes
+	}
+	data.CorrelationID = declare("correlation ID", correlationIDIdentifiers...)
+	data.Header = declare("header", headerIdentifiers...)
+	data.Retries = declare("retry", retriesIdentifiers...)
+	// API key options are named after their scheme, so they come last and fall back to With<Name>APIKey if the name is
+	// taken, e.g. by WithHeader for a scheme named header
+	for _, apiKey := range apiKeys {
+		if declared["With"+apiKey.Name] {
+			apiKey.Name += "APIKey"
+		}
+		if declare(apiKey.Scheme+" API key", "With"+apiKey.Name) {
+			data.APIKeys = append(data.APIKeys, apiKey)
+		}
+	}
+
+	if len(data.BearerSchemes) > 0 || len(data.BasicSchemes) > 0 || len(data.APIKeys) > 0 || data.CorrelationID || data.Header {
+		data.Imports = append(data.Imports, "context")
+	}
+	if data.Retries {
+		data.Imports = append(data.Imports, "io")
+	}
+	data.Imports = append(data.Imports, "net/http")
+	if data.Retries {
+		data.Imports = append(data.Imports, "strconv", "time")
+	}
+	if len(data.Imports) == 1 {
+		log.Warn().Msg("Not generating client helpers as all of their names are already declared in the package")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err = helpersTemplate.Execute(&buf, data)
+	if err != nil {
+		return errors.Wrap(err, "failed to render client helpers")
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to format client helpers")
+	}
+	return g.FileIO.Write(filepath.Join(packageDir, helpersFileName), code, 0700)
+}
+
+// declaredNames returns the package level identifiers declared in the files
+func declaredNames(files map[string][]byte) (map[string]bool, error) {
+	declared := make(map[string]bool)
+	fset := token.NewFileSet()
+	for name, data := range files {
+		file, err := parser.ParseFile(fset, name, data, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", name)
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					declared[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declared[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return declared, nil
+}
+
+var helpersTemplate = template.Must(template.New("helpers").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`// Code generated by jx3-openapi-generation. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{printf "%q" .}}
+{{- end}}
+)
+{{- if .BearerSchemes}}
+
+// TokenSource provides the bearer token for each request, e.g. refreshing it when it has expired
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token returns the token from the function
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken returns a TokenSource that always returns the same token
+func StaticToken(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithBearerToken sets the Authorization header of each request to a bearer token from the token source, for the
+// {{join .BearerSchemes ", "}} security scheme
+func WithBearerToken(tokenSource TokenSource) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// NewAuthenticatedClient creates a client that authenticates each request with a bearer token from the token source
+func NewAuthenticatedClient(server string, tokenSource TokenSource, opts ...ClientOption) (*ClientWithResponses, error) {
+	return NewClientWithResponses(server, append([]ClientOption{WithBearerToken(tokenSource)}, opts...)...)
+}
+{{- end}}
+{{- if .BasicSchemes}}
+
+// WithBasicAuth sets the Authorization header of each request to the username and password, for the
+// {{join .BasicSchemes ", "}} security scheme
+func WithBasicAuth(username, password string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+{{- end}}
+{{- range .APIKeys}}
+
+// With{{.Name}} sets the {{.ParamName}} {{.In}} of each request to the API key, for the {{.Scheme}} security scheme
+func With{{.Name}}(apiKey string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		{{- if eq .In "query"}}
+		query := req.URL.Query()
+		query.Set({{printf "%q" .ParamName}}, apiKey)
+		req.URL.RawQuery = query.Encode()
+		{{- else if eq .In "cookie"}}
+		req.AddCookie(&http.Cookie{Name: {{printf "%q" .ParamName}}, Value: apiKey})
+		{{- else}}
+		req.Header.Set({{printf "%q" .ParamName}}, apiKey)
+		{{- end}}
+		return nil
+	})
+}
+{{- end}}
+{{- if .CorrelationID}}
+
+// CorrelationIDHeader is the header WithCorrelationID sets
+const CorrelationIDHeader = "X-Correlation-ID"
+
+type correlationIDContextKey struct{}
+
+// ContextWithCorrelationID returns a context carrying the correlation ID for WithCorrelationID to set on requests
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID set by ContextWithCorrelationID, if any
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	correlationID, ok := ctx.Value(correlationIDContextKey{}).(string)
+	return correlationID, ok && correlationID != ""
+}
+
+// WithCorrelationID sets the X-Correlation-ID header of each request whose context has a correlation ID
+func WithCorrelationID() ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		if correlationID, ok := CorrelationIDFromContext(ctx); ok {
+			req.Header.Set(CorrelationIDHeader, correlationID)
+		}
+		return nil
+	})
+}
+{{- end}}
+{{- if .Header}}
+
+// WithHeader sets a header on each request, e.g. for tracing
+func WithHeader(name, value string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	})
+}
+{{- end}}
+{{- if .Retries}}
+
+// RetryPolicy is how WithRetries retries requests
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after the first attempt
+	MaxRetries int
+	// Backoff is the wait before the first retry, it doubles for each retry after that
+	Backoff time.Duration
+	// MaxBackoff caps the wait between retries, including those asked for by a Retry-After header
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries up to 3 times, waiting 100ms, 200ms and 400ms
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	Backoff:    100 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// WithRetries retries idempotent requests that fail with a network error or a 429, 502, 503 or 504 response. It wraps
+// the HTTP client, so it should come after WithHTTPClient.
+func WithRetries(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		doer := c.Client
+		if doer == nil {
+			doer = &http.Client{}
+		}
+		c.Client = &retryingRequestDoer{doer: doer, policy: policy}
+		return nil
+	}
+}
+
+type retryingRequestDoer struct {
+	doer   HttpRequestDoer
+	policy RetryPolicy
+}
+
+func (d *retryingRequestDoer) Do(req *http.Request) (*http.Response, error) {
+	if !isIdempotentMethod(req.Method) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return d.doer.Do(req)
+	}
+
+	backoff := d.policy.Backoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		rsp, err := d.doer.Do(req)
+		if attempt >= d.policy.MaxRetries || !isRetryable(rsp, err) {
+			return rsp, err
+		}
+
+		wait := backoff
+		if rsp != nil {
+			if seconds, parseErr := strconv.Atoi(rsp.Header.Get("Retry-After")); parseErr == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+		if d.policy.MaxBackoff > 0 && wait > d.policy.MaxBackoff {
+			wait = d.policy.MaxBackoff
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryable(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch rsp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+{{- end}}
+`))
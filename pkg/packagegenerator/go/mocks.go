@@ -0,0 +1,103 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/mockgen"
+)
+
+// mocksKey is the environment variable for the mock style, mockery (the default), gomock or none
+const mocksKey = "GO_MOCKS"
+
+// MocksNone disables mock generation
+const MocksNone = "none"
+
+// mockStyleFromEnvironment returns the configured mock style, defaulting to mockery
+func mockStyleFromEnvironment() string {
+	style := os.Getenv(mocksKey)
+	if style == "" {
+		return string(mockgen.Mockery)
+	}
+	return style
+}
+
+// validateMocks checks the configured mock style is supported
+func (g *Generator) validateMocks() error {
+	if g.Mocks == MocksNone {
+		return nil
+	}
+	_, err := mockgen.ParseStyle(g.Mocks)
+	return errors.Wrapf(err, "invalid %s", mocksKey)
+}
+
+// clientInterfaceMock is the mock file of the client interface, which every generated client has
+const clientInterfaceMock = "client_interface_mock.go"
+
+// generateMocks writes mocks for the interfaces in the generated client and server code alongside them. Every file in
+// the package is mocked, as the interfaces may be in any of the split files.
+func (g *Generator) generateMocks(packageDir string) error {
+	if g.Mocks == MocksNone {
+		return nil
+	}
+	style, err := mockgen.ParseStyle(g.Mocks)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s", mocksKey)
+	}
+
+	clientMocked := false
+	for _, dir := range []string{packageDir, filepath.Join(packageDir, serverPackageName)} {
+		sources, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the Go files in %s", dir)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			if strings.HasSuffix(source, "_mock.go") || strings.HasSuffix(source, "_test.go") {
+				continue
+			}
+			mocked, err := g.writeMocks(source, style)
+			if err != nil {
+				return err
+			}
+			if dir == packageDir && mocked[clientInterfaceMock] {
+				clientMocked = true
+			}
+		}
+	}
+	if !clientMocked {
+		return errors.New("no ClientInterface found in the generated client to mock")
+	}
+	return nil
+}
+
+// writeMocks writes mocks for the interfaces in the source file alongside it, returning the mock files written
+func (g *Generator) writeMocks(source string, style mockgen.Style) (map[string]bool, error) {
+	src, err := g.FileIO.Read(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", source)
+	}
+	files, err := mockgen.Generate(src, style)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate mocks for %s", source)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	written := make(map[string]bool, len(names))
+	for _, name := range names {
+		err = g.FileIO.Write(filepath.Join(filepath.Dir(source), name), files[name], 0700)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to write %s", name)
+		}
+		written[name] = true
+	}
+	return written, nil
+}
@@ -0,0 +1,268 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
+)
+
+// Environment variables for how packages are released
+const (
+	releaseBranchKey = "GO_RELEASE_BRANCH"
+	tagTimeoutKey    = "GO_TAG_TIMEOUT"
+)
+
+const (
+	defaultTagTimeout      time.Duration = 0
+	defaultTagPollInterval               = 30 * time.Second
+)
+
+// versionFileName is the file in each package directory containing the version it was generated for
+const versionFileName = "VERSION"
+
+// majorVersionDirRegex matches the major version subdirectories of a package, e.g. v2
+var majorVersionDirRegex = regexp.MustCompile(`^v\d+$`)
+
+// tagTimeoutFromEnvironment returns how long to wait for the pull request to merge before tagging it
+func tagTimeoutFromEnvironment() time.Duration {
+	value := os.Getenv(tagTimeoutKey)
+	if value == "" {
+		return defaultTagTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warn().Msgf("Invalid %s %q, using the default of %s", tagTimeoutKey, value, defaultTagTimeout)
+		return defaultTagTimeout
+	}
+	return timeout
+}
+
+// Tag returns the submodule tag for the package version, e.g. mypackage/v1.2.3. Major version subdirectories share
+// the package prefix, so mypackage/v2 is tagged mypackage/v2.0.0.
+func (g *Generator) Tag() (string, error) {
+	v, err := g.moduleVersion()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", g.GetPackageName(), v.GoModule()), nil
+}
+
+// moduleVersion returns the version the package is released as. Preview versions such as 0.0.0-PR-123-4-SNAPSHOT are
+// v0, so they're released as a prerelease of the latest major version in the repository, e.g.
+// v2.0.0-PR-123-4-SNAPSHOT, so that consumers can use them without changing their import path.
+func (g *Generator) moduleVersion() (*version.Version, error) {
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse version")
+	}
+	if v.Major() == 0 && g.previewMajor > 1 {
+		return v.WithMajor(g.previewMajor), nil
+	}
+	return v, nil
+}
+
+// latestMajorVersion returns the latest major version of the package in the repository, which is 1 unless the package
+// directory has major version subdirectories
+func latestMajorVersion(packageDir string) (uint64, error) {
+	entries, err := os.ReadDir(packageDir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, errors.Wrapf(err, "failed to read existing directory: %s", packageDir)
+	}
+
+	latest := uint64(1)
+	for _, entry := range entries {
+		if !entry.IsDir() || !majorVersionDirRegex.MatchString(entry.Name()) {
+			continue
+		}
+		major, err := strconv.ParseUint(strings.TrimPrefix(entry.Name(), "v"), 10, 64)
+		if err == nil && major > latest {
+			latest = major
+		}
+	}
+	return latest, nil
+}
+
+// packageDir returns the directory of the package in the repository, which is a major version subdirectory for v2 and
+// above so that each major version is a separate module
+func (g *Generator) packageDir(repoDir string) (string, error) {
+	versionString, err := g.getMajorVersionString()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get major version string")
+	}
+	return filepath.Join(repoDir, g.GetPackageName()+versionString), nil
+}
+
+// isPreview returns whether the version is a preview version built from a pull request, e.g. 0.0.0-PR-123-4-SNAPSHOT
+func (g *Generator) isPreview() bool {
+	v, err := g.ParsedVersion()
+	return err == nil && v.IsSnapshot()
+}
+
+// checkoutBranch checks out the branch the package is committed to. Previews are committed to their own branch, and
+// releases either to the release branch or to an update branch which is merged with a pull request.
+func (g *Generator) checkoutBranch(repoDir string) error {
+	switch {
+	case g.isPreview():
+		return g.Git.CheckoutBranch(repoDir, fmt.Sprintf("preview/%s/%s", g.GetPackageName(), g.Version))
+	case g.ReleaseBranch != "":
+		return g.Git.Checkout(repoDir, g.ReleaseBranch)
+	default:
+		return g.Git.CheckoutBranch(repoDir, fmt.Sprintf("update/%s/%s", g.GetPackageName(), g.Version))
+	}
+}
+
+// pushPreview pushes the preview branch and tags it with the prerelease version, without opening a pull request, so
+// that consumers can go get the preview version
+func (g *Generator) pushPreview(packageDir string) error {
+	currentBranch, err := g.Git.GetCurrentBranch(packageDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current branch")
+	}
+
+	err = g.Git.Push(packageDir, currentBranch)
+	if err != nil {
+		return errors.Wrap(err, "failed to Git push package")
+	}
+	return g.pushTag(packageDir)
+}
+
+// pushToReleaseBranch pushes the package straight to the release branch and tags it
+func (g *Generator) pushToReleaseBranch(packageDir string) error {
+	err := g.Git.Push(packageDir, g.ReleaseBranch)
+	if err != nil {
+		return errors.Wrap(err, "failed to Git push package")
+	}
+	return g.pushTag(packageDir)
+}
+
+// pushTag tags the current commit with the package version and pushes the tag
+func (g *Generator) pushTag(packageDir string) error {
+	tag, err := g.Tag()
+	if err != nil {
+		return err
+	}
+	return pushTag(g.Git, packageDir, tag, fmt.Sprintf("%s %s", g.GetPackageName(), g.Version))
+}
+
+// pushTag tags the current commit and pushes the tag. The tag is reused if it already points at the current commit, e.g.
+// when the pipeline is re-run for the same version.
+func pushTag(gitter domain.Gitter, dir, tag, message string) error {
+	exists, err := gitter.TagPointsAtHead(dir, tag)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for tag %s", tag)
+	}
+	if exists {
+		log.Info().Msgf("Tag %s already points at the current commit", tag)
+	} else {
+		err = gitter.CreateTag(dir, tag, message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create tag %s", tag)
+		}
+	}
+	err = gitter.PushTag(dir, tag)
+	if err != nil {
+		return errors.Wrapf(err, "failed to push tag %s", tag)
+	}
+	log.Info().Msgf("%sTagged %s%s", utils.Green, tag, utils.Reset)
+	return nil
+}
+
+// TagMergedPackages tags the packages whose version file was added or modified by the current commit of a clone of the
+// packages repository with their submodule versions, and pushes the tags. It's run on the default branch once the
+// update pull requests are merged, and returns the tags pushed.
+func TagMergedPackages(gitter domain.Gitter, fileIO domain.FileIO, repoDir string) ([]string, error) {
+	files, err := gitter.ChangedFiles(repoDir, "HEAD~1", "HEAD")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the files changed by the current commit")
+	}
+
+	var tags []string
+	for _, file := range files {
+		dir := filepath.Dir(filepath.ToSlash(file))
+		if filepath.Base(file) != versionFileName || dir == "." {
+			continue
+		}
+		data, err := fileIO.Read(filepath.Join(repoDir, file))
+		if err != nil {
+			return tags, errors.Wrapf(err, "failed to read %s", file)
+		}
+		v, err := version.Parse(strings.TrimSpace(string(data)))
+		if err != nil {
+			return tags, errors.Wrapf(err, "failed to parse the version in %s", file)
+		}
+		// Major version subdirectories share the package prefix, e.g. mypackage/v2 is tagged mypackage/v2.0.0
+		packageName := strings.Split(dir, "/")[0]
+		if v.IsSnapshot() {
+			log.Info().Msgf("Not tagging %s as %s is a preview version", packageName, v)
+			continue
+		}
+		tag := fmt.Sprintf("%s/%s", packageName, v.GoModule())
+		err = pushTag(gitter, repoDir, tag, fmt.Sprintf("%s %s", packageName, v))
+		if err != nil {
+			return tags, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// tagPullRequest waits for the pull request to be merged and tags the merge commit with the package version, if a tag
+// timeout is set. Otherwise the merge commit is tagged by TagMergedPackages.
+func (g *Generator) tagPullRequest(pr *gh.PullRequest) error {
+	if g.TagTimeout <= 0 {
+		log.Info().Msgf("%s will be tagged by the tag go-packages step once pull request %d merges", g.GetPackageName(), pr.GetNumber())
+		return nil
+	}
+
+	tag, err := g.Tag()
+	if err != nil {
+		return err
+	}
+
+	sha, err := g.waitForMerge(pr.GetNumber())
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for pull request %d to merge", pr.GetNumber())
+	}
+
+	err = g.Scm.CreateTag(context.Background(), tag, sha)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create tag %s", tag)
+	}
+	log.Info().Msgf("%sTagged %s at %s%s", utils.Green, tag, sha, utils.Reset)
+	return nil
+}
+
+// waitForMerge polls the pull request until it is merged, returning the merge commit sha
+func (g *Generator) waitForMerge(pullNumber int) (string, error) {
+	log.Info().Msgf("Waiting up to %s for pull request %d to merge", g.TagTimeout, pullNumber)
+	deadline := time.Now().Add(g.TagTimeout)
+	for {
+		pr, err := g.Scm.GetPullRequest(context.Background(), pullNumber)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get pull request")
+		}
+		if pr.GetMerged() {
+			return pr.GetMergeCommitSHA(), nil
+		}
+		if pr.GetState() == "closed" {
+			return "", errors.New("pull request was closed without merging")
+		}
+		if time.Now().Add(g.TagPollInterval).After(deadline) {
+			return "", errors.Errorf("pull request was not merged within %s", g.TagTimeout)
+		}
+		time.Sleep(g.TagPollInterval)
+	}
+}
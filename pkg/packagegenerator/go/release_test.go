@@ -0,0 +1,250 @@
+//go:build unit
+
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v47/github"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
+	_go "github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/go"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Tag(t *testing.T) {
+	testCases := []struct {
+		version     string
+		expectedTag string
+	}{
+		{version: "1.4.0", expectedTag: "testservice/v1.4.0"},
+		{version: "v0.2.1", expectedTag: "testservice/v0.2.1"},
+		{version: "2.0.0", expectedTag: "testservice/v2.0.0"},
+		{version: "0.0.0-PR-123-4-SNAPSHOT", expectedTag: "testservice/v0.0.0-PR-123-4-SNAPSHOT"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.version, func(t *testing.T) {
+			g, _ := newGenerator(t, tt.version)
+			tag, err := g.Tag()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTag, tag)
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_PreservesMajorVersions(t *testing.T) {
+	g, repoDir := newGenerator(t, "1.5.0")
+
+	v2File := filepath.Join(repoDir, "testservice", "v2", "client_generated.go")
+	staleFile := filepath.Join(repoDir, "testservice", "stale.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(v2File), 0755))
+	require.NoError(t, os.WriteFile(v2File, []byte("package testservice"), 0600))
+	require.NoError(t, os.WriteFile(staleFile, []byte("package testservice"), 0600))
+
+	_, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+	assert.FileExists(t, v2File)
+	assert.NoFileExists(t, staleFile)
+}
+
+func TestGenerator_GeneratePackage_Preview(t *testing.T) {
+	testCases := []struct {
+		name           string
+		majorDirs      []string
+		expectedDir    string
+		expectedModule string
+		expectedTag    string
+	}{
+		{
+			name:           "Package without major versions",
+			expectedDir:    "testservice",
+			expectedModule: "github.com/spring-financial-group/mqube-go-packages/testservice",
+			expectedTag:    "testservice/v0.0.0-PR-123-4-SNAPSHOT",
+		},
+		{
+			name:           "Package with major versions",
+			majorDirs:      []string{"v2", "v3"},
+			expectedDir:    "testservice/v3",
+			expectedModule: "github.com/spring-financial-group/mqube-go-packages/testservice/v3",
+			expectedTag:    "testservice/v3.0.0-PR-123-4-SNAPSHOT",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, repoDir := newGenerator(t, "0.0.0-PR-123-4-SNAPSHOT")
+			for _, dir := range tt.majorDirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "testservice", dir), 0755))
+			}
+
+			gitter := mocks.NewGitter(t)
+			gitter.On("Clone", mock.Anything, mock.Anything).Return(repoDir, nil).Once()
+			gitter.On("CheckoutBranch", repoDir, "preview/testservice/0.0.0-PR-123-4-SNAPSHOT").Return(nil).Once()
+			gitter.On("AddFiles", repoDir, mock.Anything).Return(nil).Once()
+			gitter.On("Commit", repoDir, mock.Anything).Return(nil).Once()
+			g.Git = gitter
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(repoDir, tt.expectedDir), packageDir)
+			g.Cmd.(*mocks.CommandRunner).AssertCalled(t, "ExecuteAndLog", packageDir, "go", "mod", "init", tt.expectedModule)
+
+			tag, err := g.Tag()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTag, tag)
+		})
+	}
+}
+
+func TestGenerator_PushPackage(t *testing.T) {
+	t.Run("Tags the merge commit once the pull request merges", func(t *testing.T) {
+		g, _ := newGenerator(t, "1.4.0")
+		g.TagTimeout = time.Minute
+		g.TagPollInterval = time.Millisecond
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("GetCurrentBranch", "dir").Return("update/testservice/1.4.0", nil).Once()
+		gitter.On("Push", "dir", "update/testservice/1.4.0").Return(nil).Once()
+		gitter.On("GetDefaultBranchName", "dir").Return("origin/main", nil).Once()
+		g.Git = gitter
+
+		scm := mocks.NewScmClient(t)
+		scm.On("CreatePullRequest", mock.Anything, mock.Anything).Return(&gh.PullRequest{Number: utils.NewPtr(7)}, nil).Once()
+		scm.On("AddLabels", mock.Anything, []string{"updatebot"}, 7).Return(nil, nil).Once()
+		scm.On("GetPullRequest", mock.Anything, 7).Return(&gh.PullRequest{State: utils.NewPtr("open")}, nil).Once()
+		scm.On("GetPullRequest", mock.Anything, 7).Return(&gh.PullRequest{Merged: utils.NewPtr(true), MergeCommitSHA: utils.NewPtr("abc123")}, nil).Once()
+		scm.On("CreateTag", mock.Anything, "testservice/v1.4.0", "abc123").Return(nil).Once()
+		g.Scm = scm
+
+		err := g.PushPackage("dir")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Doesn't wait for the pull request by default", func(t *testing.T) {
+		g, _ := newGenerator(t, "1.4.0")
+		assert.Zero(t, g.TagTimeout)
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("GetCurrentBranch", "dir").Return("update/testservice/1.4.0", nil).Once()
+		gitter.On("Push", "dir", "update/testservice/1.4.0").Return(nil).Once()
+		gitter.On("GetDefaultBranchName", "dir").Return("origin/main", nil).Once()
+		g.Git = gitter
+
+		scm := mocks.NewScmClient(t)
+		scm.On("CreatePullRequest", mock.Anything, mock.Anything).Return(&gh.PullRequest{Number: utils.NewPtr(7)}, nil).Once()
+		scm.On("AddLabels", mock.Anything, []string{"updatebot"}, 7).Return(nil, nil).Once()
+		g.Scm = scm
+
+		err := g.PushPackage("dir")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fails if the pull request is closed", func(t *testing.T) {
+		g, _ := newGenerator(t, "1.4.0")
+		g.TagTimeout = time.Minute
+		g.TagPollInterval = time.Millisecond
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("GetCurrentBranch", "dir").Return("update/testservice/1.4.0", nil).Once()
+		gitter.On("Push", "dir", "update/testservice/1.4.0").Return(nil).Once()
+		gitter.On("GetDefaultBranchName", "dir").Return("origin/main", nil).Once()
+		g.Git = gitter
+
+		scm := mocks.NewScmClient(t)
+		scm.On("CreatePullRequest", mock.Anything, mock.Anything).Return(&gh.PullRequest{Number: utils.NewPtr(7)}, nil).Once()
+		scm.On("AddLabels", mock.Anything, []string{"updatebot"}, 7).Return(nil, nil).Once()
+		scm.On("GetPullRequest", mock.Anything, 7).Return(&gh.PullRequest{State: utils.NewPtr("closed")}, nil).Once()
+		g.Scm = scm
+
+		err := g.PushPackage("dir")
+		assert.ErrorContains(t, err, "closed without merging")
+	})
+
+	t.Run("Pushes and tags the preview branch without a pull request", func(t *testing.T) {
+		g, _ := newGenerator(t, "0.0.0-PR-123-4-SNAPSHOT")
+		g.ReleaseBranch = "release"
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("GetCurrentBranch", "dir").Return("preview/testservice/0.0.0-PR-123-4-SNAPSHOT", nil).Once()
+		gitter.On("Push", "dir", "preview/testservice/0.0.0-PR-123-4-SNAPSHOT").Return(nil).Once()
+		gitter.On("TagPointsAtHead", "dir", "testservice/v0.0.0-PR-123-4-SNAPSHOT").Return(false, nil).Once()
+		gitter.On("CreateTag", "dir", "testservice/v0.0.0-PR-123-4-SNAPSHOT", mock.Anything).Return(nil).Once()
+		gitter.On("PushTag", "dir", "testservice/v0.0.0-PR-123-4-SNAPSHOT").Return(nil).Once()
+		g.Git = gitter
+		g.Scm = mocks.NewScmClient(t)
+
+		err := g.PushPackage("dir")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Pushes and tags the release branch", func(t *testing.T) {
+		g, _ := newGenerator(t, "2.0.0")
+		g.ReleaseBranch = "release"
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("Push", "dir", "release").Return(nil).Once()
+		gitter.On("TagPointsAtHead", "dir", "testservice/v2.0.0").Return(false, nil).Once()
+		gitter.On("CreateTag", "dir", "testservice/v2.0.0", mock.Anything).Return(nil).Once()
+		gitter.On("PushTag", "dir", "testservice/v2.0.0").Return(nil).Once()
+		g.Git = gitter
+		g.Scm = mocks.NewScmClient(t)
+
+		err := g.PushPackage("dir")
+		assert.NoError(t, err)
+	})
+	t.Run("Reuses the tag when the release branch is pushed again", func(t *testing.T) {
+		g, _ := newGenerator(t, "2.0.0")
+		g.ReleaseBranch = "release"
+
+		gitter := mocks.NewGitter(t)
+		gitter.On("Push", "dir", "release").Return(nil).Once()
+		gitter.On("TagPointsAtHead", "dir", "testservice/v2.0.0").Return(true, nil).Once()
+		gitter.On("PushTag", "dir", "testservice/v2.0.0").Return(nil).Once()
+		g.Git = gitter
+		g.Scm = mocks.NewScmClient(t)
+
+		err := g.PushPackage("dir")
+		assert.NoError(t, err)
+		gitter.AssertNotCalled(t, "CreateTag", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTagMergedPackages(t *testing.T) {
+	repoDir := t.TempDir()
+	versions := map[string]string{
+		"testservice/VERSION":     "1.4.0",
+		"otherservice/v2/VERSION": "2.1.0",
+		"previewservice/VERSION":  "0.0.0-PR-123-4-SNAPSHOT",
+	}
+	for name, v := range versions {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, name), []byte(v), 0600))
+	}
+
+	gitter := mocks.NewGitter(t)
+	gitter.On("ChangedFiles", repoDir, "HEAD~1", "HEAD").Return([]string{
+		"README.md",
+		"VERSION",
+		"otherservice/v2/VERSION",
+		"otherservice/v2/client_generated.go",
+		"previewservice/VERSION",
+		"testservice/VERSION",
+	}, nil).Once()
+	gitter.On("TagPointsAtHead", repoDir, "otherservice/v2.1.0").Return(true, nil).Once()
+	gitter.On("PushTag", repoDir, "otherservice/v2.1.0").Return(nil).Once()
+	gitter.On("TagPointsAtHead", repoDir, "testservice/v1.4.0").Return(false, nil).Once()
+	gitter.On("CreateTag", repoDir, "testservice/v1.4.0", "testservice 1.4.0").Return(nil).Once()
+	gitter.On("PushTag", repoDir, "testservice/v1.4.0").Return(nil).Once()
+
+	tags, err := _go.TagMergedPackages(gitter, file.NewFileIO(), repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otherservice/v2.1.0", "testservice/v1.4.0"}, tags)
+}
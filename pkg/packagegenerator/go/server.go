@@ -0,0 +1,93 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/pkg/errors"
+)
+
+// Environment variables for the server generation options
+const (
+	serverKey       = "GO_SERVER"
+	strictServerKey = "GO_STRICT_SERVER"
+)
+
+// Server frameworks that server interfaces can be generated for
+const (
+	ServerStdHTTP = "std-http"
+	ServerChi     = "chi"
+	ServerEcho    = "echo"
+	ServerGin     = "gin"
+)
+
+const (
+	serverPackageName = "server"
+	serverFileName    = "server_generated.go"
+)
+
+// serverGenerateOptions returns the oapi-codegen options for the configured server framework
+func (g *Generator) serverGenerateOptions() (codegen.GenerateOptions, error) {
+	opts := codegen.GenerateOptions{
+		Strict:       g.StrictServer,
+		EmbeddedSpec: true,
+	}
+	switch g.Server {
+	case ServerStdHTTP:
+		opts.StdHTTPServer = true
+	case ServerChi:
+		opts.ChiServer = true
+	case ServerEcho:
+		opts.EchoServer = true
+	case ServerGin:
+		opts.GinServer = true
+	default:
+		return opts, errors.Errorf("unsupported %s %q, must be one of %s, %s, %s or %s", serverKey, g.Server, ServerStdHTTP, ServerChi, ServerEcho, ServerGin)
+	}
+	return opts, nil
+}
+
+// generateServer generates the server interfaces and embedded spec into the server subpackage. The models are not
+// regenerated, the client package is dot imported so that the server code can refer to them unqualified.
+func (g *Generator) generateServer(swagger *openapi3.T, packageDir string) error {
+	opts, err := g.serverGenerateOptions()
+	if err != nil {
+		return err
+	}
+
+	moduleName, err := g.moduleName()
+	if err != nil {
+		return errors.Wrap(err, "failed to get module name")
+	}
+
+	config, err := g.codegenConfiguration(serverPackageName, opts, codegen.AdditionalImport{Alias: ".", Package: moduleName})
+	if err != nil {
+		return err
+	}
+
+	code, err := codegen.Generate(swagger, config)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate server code")
+	}
+
+	serverDir, err := g.FileIO.MkdirAll(filepath.Join(packageDir, serverPackageName), 0700)
+	if err != nil {
+		return errors.Wrap(err, "failed to create server package directory")
+	}
+	err = g.FileIO.Write(filepath.Join(serverDir, serverFileName), []byte(code), 0700)
+	if err != nil {
+		return errors.Wrap(err, "failed to write server code to file")
+	}
+	return nil
+}
+
+// serverDescription describes the server options for logs and cache keys
+func (g *Generator) serverDescription() string {
+	if g.Server == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s (strict: %t)", g.Server, g.StrictServer)
+}
@@ -0,0 +1,247 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// splitFilesKey is the environment variable to split the generated client into models, client and per-tag files
+const splitFilesKey = "GO_SPLIT_FILES"
+
+const (
+	clientFileName      = "client_generated.go"
+	splitModelsFileName = "models_gen.go"
+	splitClientFileName = "client_gen.go"
+	splitFileSuffix     = "_gen.go"
+)
+
+var (
+	nonAlphanumericRegex = regexp.MustCompile(`[^a-z0-9]+`)
+	majorVersionRegex    = regexp.MustCompile(`^v[0-9]+$`)
+	versionSuffixRegex   = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// splitFilesFromEnvironment returns whether the generated client should be split into multiple files
+func splitFilesFromEnvironment() bool {
+	return os.Getenv(splitFilesKey) == "true"
+}
+
+// clientFile returns the name of the file containing the client, which the mocks are generated from
+func (g *Generator) clientFile() string {
+	if g.SplitFiles {
+		return splitClientFileName
+	}
+	return clientFileName
+}
+
+// clientFiles returns the generated client code by file name, split into models, client and per-tag files if enabled
+func (g *Generator) clientFiles(code string, swagger *openapi3.T) (map[string][]byte, error) {
+	if !g.SplitFiles {
+		return map[string][]byte{clientFileName: []byte(code)}, nil
+	}
+	return splitCode([]byte(code), swagger)
+}
+
+// splitFile is the declarations of one of the split files
+type splitFile struct {
+	decls  []ast.Decl
+	chunks [][]byte
+}
+
+// splitCode splits generated code into models_gen.go for the schemas, <tag>_gen.go for the operations of each tag and
+// client_gen.go for everything else. The declarations are copied verbatim, so the split files only differ from the
+// single file in where the declarations are.
+func splitCode(src []byte, swagger *openapi3.T) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse generated code")
+	}
+
+	elements := specElements(swagger)
+	files := make(map[string]*splitFile)
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			continue
+		}
+
+		name := splitClientFileName
+		if element, ok := matchSpecElement(declarationName(decl), elements); ok {
+			switch {
+			case !element.operation:
+				name = splitModelsFileName
+			case element.tag != "":
+				name = tagFileName(element.tag)
+			}
+		}
+		if files[name] == nil {
+			files[name] = &splitFile{}
+		}
+
+		start := decl.Pos()
+		if doc := declarationDoc(decl); doc != nil {
+			start = doc.Pos()
+		}
+		files[name].decls = append(files[name].decls, decl)
+		files[name].chunks = append(files[name].chunks, src[fset.Position(start).Offset:fset.Position(decl.End()).Offset])
+	}
+	if files[splitClientFileName] == nil {
+		files[splitClientFileName] = &splitFile{}
+	}
+
+	// The package doc is kept in the client file, the others only get the generated code header
+	packageOffset := fset.Position(file.Package).Offset
+	header := src[:packageOffset]
+	generatedHeader := generatedCodeHeader(file)
+
+	result := make(map[string][]byte, len(files))
+	for name, f := range files {
+		var buf bytes.Buffer
+		if name == splitClientFileName {
+			buf.Write(header)
+		} else if generatedHeader != "" {
+			buf.WriteString(generatedHeader + "\n\n")
+		}
+		buf.WriteString("package " + file.Name.Name + "\n\n")
+
+		imports := usedImports(file, f.decls, name == splitClientFileName)
+		if len(imports) > 0 {
+			buf.WriteString("import (\n")
+			for i, imp := range imports {
+				if i > 0 && isStandardImport(imports[i-1]) && !isStandardImport(imp) {
+					buf.WriteString("\n")
+				}
+				buf.WriteString("\t" + imp + "\n")
+			}
+			buf.WriteString(")\n\n")
+		}
+		buf.Write(bytes.Join(f.chunks, []byte("\n\n")))
+		buf.WriteString("\n")
+
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to format %s", name)
+		}
+		result[name] = formatted
+	}
+	return result, nil
+}
+
+// tagFileName returns the file name for the operations of a tag, e.g. Pet Store -> pet_store_gen.go
+func tagFileName(tag string) string {
+	name := strings.Trim(nonAlphanumericRegex.ReplaceAllString(strings.ToLower(tag), "_"), "_")
+	if name == "" {
+		return splitClientFileName
+	}
+	if name+splitFileSuffix == splitModelsFileName || name+splitFileSuffix == splitClientFileName {
+		name += "_operations"
+	}
+	return name + splitFileSuffix
+}
+
+func declarationDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// generatedCodeHeader returns the "Code generated ... DO NOT EDIT." comment of the file, if it has one
+func generatedCodeHeader(file *ast.File) string {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated ") {
+				return comment.Text
+			}
+		}
+	}
+	return ""
+}
+
+// usedImports returns the imports of the file that the declarations refer to. Blank and dot imports are only kept in
+// the client file.
+func usedImports(file *ast.File, decls []ast.Decl, client bool) []string {
+	used := make(map[string]bool)
+	for _, decl := range decls {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	var imports []string
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == "_" || imp.Name.Name == "." {
+				if client {
+					imports = append(imports, imp.Name.Name+" "+imp.Path.Value)
+				}
+			} else if used[imp.Name.Name] {
+				imports = append(imports, imp.Name.Name+" "+imp.Path.Value)
+			}
+			continue
+		}
+		if used[importName(importPath)] {
+			imports = append(imports, imp.Path.Value)
+		}
+	}
+	// Standard library imports first, as goimports groups them
+	sort.SliceStable(imports, func(i, j int) bool {
+		if isStandardImport(imports[i]) != isStandardImport(imports[j]) {
+			return isStandardImport(imports[i])
+		}
+		return importPath(imports[i]) < importPath(imports[j])
+	})
+	return imports
+}
+
+// importPath returns the quoted path of an import spec, e.g. "fmt" for openapi_types "fmt"
+func importPath(spec string) string {
+	return spec[strings.LastIndex(spec, " ")+1:]
+}
+
+// isStandardImport returns whether an import spec is of a standard library package, which have no dot in the first
+// path element
+func isStandardImport(spec string) bool {
+	path, _ := strconv.Unquote(importPath(spec))
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// importName returns the package name an unaliased import is conventionally referred to by, e.g. gopkg.in/yaml.v3 ->
+// yaml and github.com/go-chi/chi/v5 -> chi
+func importName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if majorVersionRegex.MatchString(name) && len(parts) > 1 {
+		name = parts[len(parts)-2]
+	}
+	name = versionSuffixRegex.ReplaceAllString(name, "")
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
@@ -0,0 +1,118 @@
+//nolint:staticcheck // package name _go is required to avoid conflict with Go keyword
+package _go
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// goErrorRegex matches the file positions in go build and go vet output, e.g. ./client_generated.go:12:5: message
+var goErrorRegex = regexp.MustCompile(`^(?:vet: )?(\.?[^\s:]+\.go):(\d+)(?::\d+)?: (.+)$`)
+
+// VerificationProblem is a compile or vet error in the generated package
+type VerificationProblem struct {
+	File    string
+	Line    int
+	Message string
+	// Declaration is the Go declaration containing the error, if it could be found
+	Declaration string
+	// SpecElement is the schema or operation in the spec that the declaration was generated from, if it could be found
+	SpecElement string
+}
+
+func (p VerificationProblem) String() string {
+	s := fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	if p.SpecElement != "" {
+		s += fmt.Sprintf(" (generated from %s)", p.SpecElement)
+	} else if p.Declaration != "" {
+		s += fmt.Sprintf(" (in %s)", p.Declaration)
+	}
+	return s
+}
+
+// VerificationError is returned when the generated package fails to build or vet
+type VerificationError struct {
+	Command  string
+	Output   string
+	Problems []VerificationProblem
+}
+
+func (e *VerificationError) Error() string {
+	if len(e.Problems) == 0 {
+		return fmt.Sprintf("%s failed on the generated package: %s", e.Command, e.Output)
+	}
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.String()
+	}
+	return fmt.Sprintf("%s failed on the generated package:\n%s", e.Command, strings.Join(problems, "\n"))
+}
+
+// verifyPackage builds and vets the generated package so that code that doesn't compile is never committed
+func (g *Generator) verifyPackage(packageDir string, swagger *openapi3.T) error {
+	log.Info().Msgf("%sVerifying generated package%s", utils.Cyan, utils.Reset)
+	for _, args := range [][]string{{"build", "./..."}, {"vet", "./..."}} {
+		out, err := g.Cmd.Execute(packageDir, "go", args...)
+		if err != nil {
+			return &VerificationError{
+				Command:  "go " + strings.Join(args, " "),
+				Output:   out,
+				Problems: g.parseProblems(packageDir, out, swagger),
+			}
+		}
+	}
+	return nil
+}
+
+// parseProblems finds the file positions in the output and the spec elements they were generated from
+func (g *Generator) parseProblems(packageDir, output string, swagger *openapi3.T) []VerificationProblem {
+	elements := specElements(swagger)
+	files := make(map[string]*ast.File)
+	fset := token.NewFileSet()
+
+	var problems []VerificationProblem
+	for _, line := range strings.Split(output, "\n") {
+		match := goErrorRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(match[2])
+		problem := VerificationProblem{
+			File:    filepath.ToSlash(filepath.Clean(match[1])),
+			Line:    lineNumber,
+			Message: match[3],
+		}
+
+		path := match[1]
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(packageDir, path)
+		}
+		file, ok := files[path]
+		if !ok {
+			src, err := g.FileIO.Read(path)
+			if err == nil {
+				// A partial AST is still returned for files with syntax errors
+				file, _ = parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+			}
+			files[path] = file
+		}
+		if file != nil {
+			problem.Declaration = enclosingDeclaration(fset, file, lineNumber)
+			if element, ok := matchSpecElement(problem.Declaration, elements); ok {
+				problem.SpecElement = element.description
+			}
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
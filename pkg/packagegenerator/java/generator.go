@@ -2,59 +2,130 @@ package java
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
 )
 
 const (
-	packagingFilesDir = "/templates/java"
+	packagingFilesDir = "java"
 )
 
 type Generator struct {
 	*packagegenerator.BaseGenerator
+
+	// GroupID is the Maven groupId of the package, derived from the repository name if not set
+	GroupID string
+	// ArtifactID is the Maven artifactId of the package
+	ArtifactID string
+	// PackageRoot is the Java package the generated code is placed under, derived from the service name if not set
+	PackageRoot string
+	// Library is the openapi-generator java library, overriding the config if set
+	Library string
 }
 
 func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 	return &Generator{
 		BaseGenerator: baseGenerator,
+		GroupID:       os.Getenv(groupIDKey),
+		ArtifactID:    artifactIDFromEnvironment(),
+		PackageRoot:   os.Getenv(packageRootKey),
+		Library:       os.Getenv(libraryKey),
+	}
+}
+
+// GenerationOptions returns the coordinates, package root and library, which change the generated package
+func (g *Generator) GenerationOptions() []string {
+	return []string{
+		"group-id=" + g.GroupID,
+		"artifact-id=" + g.ArtifactID,
+		"package-root=" + g.PackageRoot,
+		"library=" + g.Library,
 	}
 }
 
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
-	g.setDynamicConfigVariables()
+	generatedDir, err := g.PrepareGeneration(outputDir)
+	if err != nil {
+		return "", err
+	}
 
-	packageDir, err := g.BaseGenerator.GeneratePackage(filepath.Join(outputDir, g.GetPackageName()), domain.Java)
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.Java)
 	if err != nil {
 		return "", err
 	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
 
-	if err = g.FileIO.TemplateFilesInDir(packagingFilesDir, packageDir, g); err != nil {
+// PrepareGeneration sets the java specific config variables and the output directory
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	if err := g.validateOptions(); err != nil {
 		return "", err
 	}
+	g.setDynamicConfigVariables()
+	return g.SetOutput(filepath.Join(outputDir, g.GetPackageName()), domain.Java)
+}
 
-	return packageDir, nil
+// CompleteGeneration templates the packaging files into the generated package
+func (g *Generator) CompleteGeneration(_, generatedDir string) (string, error) {
+	if err := g.FileIO.TemplateFilesInDir(g.Templates, packagingFilesDir, generatedDir, g); err != nil {
+		return "", err
+	}
+	return generatedDir, nil
+}
+
+// ResolveConfig returns the openapi-generator config with the java specific variables set
+func (g *Generator) ResolveConfig() *openapitools.Config {
+	g.setDynamicConfigVariables()
+	return g.Cfg
 }
 
 func (g *Generator) setDynamicConfigVariables() {
-	g.Cfg.GeneratorCLI.Generators[domain.Java].AdditionalProperties["basePackage"] = g.getModelName()
-	g.Cfg.GeneratorCLI.Generators[domain.Java].AdditionalProperties["modelPackage"] = fmt.Sprintf("%s.models", g.getModelName())
+	generator := g.Cfg.GeneratorCLI.Generators[domain.Java]
+	generator.AdditionalProperties["basePackage"] = g.getModelName()
+	generator.AdditionalProperties["modelPackage"] = fmt.Sprintf("%s.models", g.getModelName())
+	if g.Library != "" {
+		generator.AdditionalProperties["library"] = g.Library
+	}
 
-	if g.Cfg.GeneratorCLI.Generators[domain.Java].GlobalProperty == nil {
-		g.Cfg.GeneratorCLI.Generators[domain.Java].GlobalProperty = make(map[string]string)
+	if generator.GlobalProperty == nil {
+		generator.GlobalProperty = make(openapitools.Properties)
+	}
+	library, ok := libraries[g.ClientLibrary()]
+	if !ok {
+		return
+	}
+	generator.AdditionalProperties["serializationLibrary"] = library.serializationLibrary
+	if !library.client {
+		generator.GlobalProperty["supportingFiles"] = "AbstractOpenApiSchema.java:JSON.java:ApiException.java"
+		return
 	}
-	g.Cfg.GeneratorCLI.Generators[domain.Java].GlobalProperty["supportingFiles"] = "AbstractOpenApiSchema.java:JSON.java:ApiException.java"
+	// The client libraries generate the apis, and all the supporting files they need, under the package root
+	generator.AdditionalProperties["invokerPackage"] = g.getModelName()
+	generator.AdditionalProperties["apiPackage"] = fmt.Sprintf("%s.api", g.getModelName())
+	generator.GlobalProperty["apis"] = ""
+	generator.GlobalProperty["supportingFiles"] = ""
+	generator.GlobalProperty["apiTests"] = false
+	generator.GlobalProperty["apiDocs"] = false
 }
 
 func (g *Generator) GetPackageName() string {
+	if g.GroupID != "" {
+		return g.GroupID
+	}
 	// Replace first hyphen with a dot mqube-foo-service -> mqube.foo-service
 	return strings.Replace(g.RepoName, "-", ".", 1)
 }
 
 func (g *Generator) getModelName() string {
+	if g.PackageRoot != "" {
+		return g.PackageRoot
+	}
 	// convert pascal case to camel case
 	return fmt.Sprintf("mqube.%s", utils.FirstCharToLower(g.ServiceName))
 }
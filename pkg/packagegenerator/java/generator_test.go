@@ -96,7 +96,7 @@ func TestJavaGeneratorFixesIssues(t *testing.T) {
 					Name:      "java",
 					InputSpec: specFile,
 					Output:    outputDir,
-					AdditionalProperties: map[string]string{
+					AdditionalProperties: openapitools.Properties{
 						"library":              "okhttp-gson",
 						"serializationLibrary": "gson",
 					},
@@ -128,8 +128,8 @@ func TestJavaGeneratorFixesIssues(t *testing.T) {
 	cfg.GeneratorCLI.Generators["java"].AdditionalProperties["modelPackage"] = "mqube.test-service.models"
 
 	// Generate the package - this is what was failing before
-	// Note: We skip the template copying step that requires /templates/java since
-	// that's only needed in the containerized environment for build.gradle templating
+	// Note: We skip the build.gradle templating step since the compile test below renders it and removes the
+	// publishing section
 	generatedDir, err := baseGen.GeneratePackage(filepath.Join(outputDir, javaGen.GetPackageName()), domain.Java)
 	require.NoError(t, err, "Java package generation should succeed")
 
@@ -181,18 +181,12 @@ func TestJavaGeneratorFixesIssues(t *testing.T) {
 	})
 
 	t.Run("generated code compiles with updated dependencies", func(t *testing.T) {
-		// Copy our updated build.gradle template
-		templatePath := filepath.Join("..", "..", "..", "templates", "java", "build.gradle")
-		template, err := os.ReadFile(templatePath)
+		// Render our build.gradle template into the generated package
+		_, err := javaGen.CompleteGeneration(outputDir, generatedDir)
 		require.NoError(t, err)
-
-		// Replace template variables
-		buildContent := string(template)
-		buildContent = strings.ReplaceAll(buildContent, "{{ .GetPackageName }}", "com.test.generated")
-		buildContent = strings.ReplaceAll(buildContent, "{{ .Version }}", "1.0.0-test")
-		buildContent = strings.ReplaceAll(buildContent, "{{ .RepoName }}", "test-repo")
-		buildContent = strings.ReplaceAll(buildContent, "{{ .GitUser }}", "test-user")
-		buildContent = strings.ReplaceAll(buildContent, "{{ .GitToken }}", "test-token")
+		build, err := os.ReadFile(filepath.Join(generatedDir, "build.gradle"))
+		require.NoError(t, err)
+		buildContent := string(build)
 
 		// Remove publishing section for test
 		lines := strings.Split(buildContent, "\n")
@@ -0,0 +1,99 @@
+package java
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+)
+
+const (
+	// groupIDKey is the environment variable for the Maven groupId of the package
+	groupIDKey = "JAVA_GROUP_ID"
+	// artifactIDKey is the environment variable for the Maven artifactId of the package
+	artifactIDKey = "JAVA_ARTIFACT_ID"
+	// packageRootKey is the environment variable for the Java package the generated code is placed under
+	packageRootKey = "JAVA_PACKAGE_ROOT"
+	// libraryKey is the environment variable for the openapi-generator java library, overriding the config
+	libraryKey = "JAVA_LIBRARY"
+
+	defaultArtifactID = "java"
+)
+
+// Libraries supported by the openapi-generator java generator
+const (
+	LibraryOkHttpGson   = "okhttp-gson"
+	LibraryNative       = "native"
+	LibraryWebClient    = "webclient"
+	LibraryRestTemplate = "resttemplate"
+	LibraryFeign        = "feign"
+	LibraryRetrofit2    = "retrofit2"
+)
+
+// library describes how the package is generated with an openapi-generator java library
+type library struct {
+	serializationLibrary string
+	// client is whether the apis and all their supporting files are generated, rather than just the models
+	client      bool
+	javaVersion string
+}
+
+var libraries = map[string]library{
+	LibraryOkHttpGson:   {serializationLibrary: "gson", javaVersion: "1_8"},
+	LibraryNative:       {serializationLibrary: "jackson", client: true, javaVersion: "11"},
+	LibraryWebClient:    {serializationLibrary: "jackson", client: true, javaVersion: "1_8"},
+	LibraryRestTemplate: {serializationLibrary: "jackson", client: true, javaVersion: "1_8"},
+	LibraryFeign:        {serializationLibrary: "jackson", client: true, javaVersion: "1_8"},
+	LibraryRetrofit2:    {serializationLibrary: "gson", client: true, javaVersion: "1_8"},
+}
+
+var (
+	coordinateRegex  = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	packageRootRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+)
+
+func artifactIDFromEnvironment() string {
+	artifactID := os.Getenv(artifactIDKey)
+	if artifactID == "" {
+		return defaultArtifactID
+	}
+	return artifactID
+}
+
+// ClientLibrary returns the library the package is generated with
+func (g *Generator) ClientLibrary() string {
+	if g.Library != "" {
+		return g.Library
+	}
+	library, _ := g.Cfg.GeneratorCLI.Generators[domain.Java].AdditionalProperties["library"].(string)
+	return library
+}
+
+// JavaVersion returns the Java version the package is compiled for, as the suffix of a gradle JavaVersion
+func (g *Generator) JavaVersion() string {
+	return libraries[g.ClientLibrary()].javaVersion
+}
+
+func (g *Generator) validateOptions() error {
+	if _, ok := libraries[g.ClientLibrary()]; !ok {
+		supported := make([]string, 0, len(libraries))
+		for name := range libraries {
+			supported = append(supported, name)
+		}
+		slices.Sort(supported)
+		return errors.Errorf("unsupported java library %q, must be one of %s", g.ClientLibrary(), strings.Join(supported, ", "))
+	}
+	if g.GroupID != "" && !coordinateRegex.MatchString(g.GroupID) {
+		return errors.Errorf("invalid %s %q", groupIDKey, g.GroupID)
+	}
+	if !coordinateRegex.MatchString(g.ArtifactID) {
+		return errors.Errorf("invalid %s %q", artifactIDKey, g.ArtifactID)
+	}
+	if g.PackageRoot != "" && !packageRootRegex.MatchString(g.PackageRoot) {
+		return errors.Errorf("invalid %s %q, must be a Java package name", packageRootKey, g.PackageRoot)
+	}
+	return nil
+}
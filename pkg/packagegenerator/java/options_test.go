@@ -0,0 +1,171 @@
+//go:build unit
+
+package java_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/java"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/packagegeneratortest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGenerator(t *testing.T) (*java.Generator, *packagegeneratortest.Generator) {
+	base, fake := packagegeneratortest.NewBaseGenerator(t, domain.Java, packagegeneratortest.Options{RepoName: "mqube-test-service"})
+	return java.NewGenerator(base), fake
+}
+
+func TestGenerator_GeneratePackage_Options(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		groupID               string
+		artifactID            string
+		packageRoot           string
+		library               string
+		expectedDir           string
+		expectedProperties    openapitools.Properties
+		expectedGlobal        openapitools.Properties
+		expectedBuild         []string
+		expectedMissingBuild  []string
+		expectedMissingConfig []string
+		expectedErr           string
+	}{
+		{
+			name:        "Defaults",
+			artifactID:  "java",
+			expectedDir: "mqube.test-service",
+			expectedProperties: openapitools.Properties{
+				"library":              java.LibraryOkHttpGson,
+				"serializationLibrary": "gson",
+				"modelPackage":         "mqube.testService.models",
+			},
+			expectedGlobal: openapitools.Properties{
+				"supportingFiles": "AbstractOpenApiSchema.java:JSON.java:ApiException.java",
+			},
+			expectedBuild: []string{
+				"group = 'mqube.test-service'",
+				"artifactId = 'java'",
+				"sourceCompatibility = JavaVersion.VERSION_1_8",
+				"implementation 'com.squareup.okhttp3:okhttp:4.12.0'",
+				"implementation 'io.gsonfire:gson-fire:1.9.0'",
+			},
+			expectedMissingBuild:  []string{"jackson-databind:", "retrofit"},
+			expectedMissingConfig: []string{"apiPackage", "invokerPackage"},
+		},
+		{
+			name:        "Native client with coordinates",
+			groupID:     "com.mqube.clients",
+			artifactID:  "test-service-client",
+			packageRoot: "com.mqube.testservice",
+			library:     java.LibraryNative,
+			expectedDir: "com.mqube.clients",
+			expectedProperties: openapitools.Properties{
+				"library":              java.LibraryNative,
+				"serializationLibrary": "jackson",
+				"modelPackage":         "com.mqube.testservice.models",
+				"apiPackage":           "com.mqube.testservice.api",
+				"invokerPackage":       "com.mqube.testservice",
+			},
+			expectedGlobal: openapitools.Properties{
+				"apis":            "",
+				"supportingFiles": "",
+			},
+			expectedBuild: []string{
+				"group = 'com.mqube.clients'",
+				"artifactId = 'test-service-client'",
+				"sourceCompatibility = JavaVersion.VERSION_11",
+				"implementation 'com.fasterxml.jackson.core:jackson-databind:2.17.2'",
+				"implementation 'org.apache.httpcomponents:httpmime:4.5.14'",
+			},
+			expectedMissingBuild: []string{"okhttp", "gson"},
+		},
+		{
+			name:        "Feign client",
+			artifactID:  "java",
+			library:     java.LibraryFeign,
+			expectedDir: "mqube.test-service",
+			expectedProperties: openapitools.Properties{
+				"library":              java.LibraryFeign,
+				"serializationLibrary": "jackson",
+				"apiPackage":           "mqube.testService.api",
+			},
+			expectedBuild: []string{
+				"implementation 'io.github.openfeign:feign-core:13.2.1'",
+				"implementation 'com.fasterxml.jackson.datatype:jackson-datatype-jsr310:2.17.2'",
+			},
+			expectedMissingBuild: []string{"gson", "springframework"},
+		},
+		{
+			name:        "Retrofit client",
+			artifactID:  "java",
+			library:     java.LibraryRetrofit2,
+			expectedDir: "mqube.test-service",
+			expectedProperties: openapitools.Properties{
+				"library":              java.LibraryRetrofit2,
+				"serializationLibrary": "gson",
+			},
+			expectedBuild: []string{
+				"implementation 'com.squareup.retrofit2:converter-gson:2.11.0'",
+				"implementation 'com.google.code.gson:gson:2.13.2'",
+			},
+			expectedMissingBuild: []string{"jackson-databind:", "logging-interceptor"},
+		},
+		{
+			name:        "Unsupported library",
+			artifactID:  "java",
+			library:     "jersey3",
+			expectedErr: `unsupported java library "jersey3", must be one of feign, native, okhttp-gson, resttemplate, retrofit2, webclient`,
+		},
+		{
+			name:        "Invalid package root",
+			artifactID:  "java",
+			packageRoot: "com.mqube.test-service",
+			expectedErr: `invalid JAVA_PACKAGE_ROOT "com.mqube.test-service", must be a Java package name`,
+		},
+		{
+			name:        "Invalid artifact ID",
+			artifactID:  "test service",
+			expectedErr: `invalid JAVA_ARTIFACT_ID "test service"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, fake := newGenerator(t)
+			g.GroupID, g.ArtifactID, g.PackageRoot, g.Library = tt.groupID, tt.artifactID, tt.packageRoot, tt.library
+
+			outputDir := t.TempDir()
+			packageDir, err := g.GeneratePackage(outputDir)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(outputDir, tt.expectedDir), packageDir)
+
+			for key, value := range tt.expectedProperties {
+				assert.Equal(t, value, fake.Generator.AdditionalProperties[key], key)
+			}
+			for key, value := range tt.expectedGlobal {
+				assert.Equal(t, value, fake.Generator.GlobalProperty[key], key)
+			}
+			for _, key := range tt.expectedMissingConfig {
+				assert.NotContains(t, fake.Generator.AdditionalProperties, key)
+			}
+
+			build, err := os.ReadFile(filepath.Join(packageDir, "build.gradle"))
+			require.NoError(t, err)
+			for _, expected := range tt.expectedBuild {
+				assert.Contains(t, string(build), expected)
+			}
+			for _, missing := range tt.expectedMissingBuild {
+				assert.NotContains(t, string(build), missing)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package javascript
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -10,16 +11,17 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
 )
 
 const (
-	packagingFilesDir = "/templates/javascript"
+	packagingFilesDir = "javascript"
 )
 
 // Paths for use in generating angular packages
 var (
-	npmrcPath       = filepath.Join(packagingFilesDir, ".npmrc")
-	packageJSONPath = filepath.Join(packagingFilesDir, "package.json")
+	npmrcPath       = path.Join(packagingFilesDir, ".npmrc")
+	packageJSONPath = path.Join(packagingFilesDir, "package.json")
 )
 
 // Packages installed by the generator
@@ -38,23 +40,37 @@ func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 }
 
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
-	packageDir, err := g.BaseGenerator.GeneratePackage(filepath.Join(outputDir, g.GetPackageName()), domain.Javascript)
+	generatedDir, err := g.PrepareGeneration(outputDir)
 	if err != nil {
 		return "", err
 	}
 
-	err = g.Cmd.ExecuteAndLog(packageDir, "npm", "install")
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.Javascript)
+	if err != nil {
+		return "", err
+	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
+
+// PrepareGeneration sets the output directory
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	return g.SetOutput(filepath.Join(outputDir, g.GetPackageName()), domain.Javascript)
+}
+
+// CompleteGeneration builds the generated package and templates the package files into the dist directory
+func (g *Generator) CompleteGeneration(_, generatedDir string) (string, error) {
+	err := g.Cmd.ExecuteAndLog(generatedDir, "npm", "install")
 	if err != nil {
 		return "", errors.Wrap(err, "failed to run npm install")
 	}
 
-	err = g.Cmd.ExecuteAndLog(packageDir, "npm", "run", "build")
+	err = g.Cmd.ExecuteAndLog(generatedDir, "npm", "run", "build")
 	if err != nil {
 		return "", errors.Wrap(err, "failed to run npm build")
 	}
 
-	distDir := filepath.Join(packageDir, "dist")
-	if err = g.FileIO.TemplateFiles(distDir, g, packageJSONPath, npmrcPath); err != nil {
+	distDir := filepath.Join(generatedDir, "dist")
+	if err = g.FileIO.TemplateFiles(g.Templates, distDir, g, packageJSONPath, npmrcPath); err != nil {
 		return "", err
 	}
 	return distDir, nil
@@ -65,15 +81,17 @@ func (g *Generator) GetPackageName() string {
 }
 
 func (g *Generator) PushPackage(packageDir string) error {
-	// Determine npm publish args - prerelease versions need a tag
-	var out string
-	var err error
-	if strings.Contains(g.Version, "-") {
-		// This is a prerelease version (e.g., contains -SNAPSHOT, -PR-, -alpha, etc.)
-		out, err = g.Cmd.Execute(packageDir, "npm", "publish", "--tag", "preview")
-	} else {
-		out, err = g.Cmd.Execute(packageDir, "npm", "publish")
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return err
+	}
+
+	// Prereleases (e.g. -SNAPSHOT, -PR-, -alpha) are published under their own dist-tag
+	args := []string{"publish"}
+	if distTag := v.NPMDistTag(); distTag != version.NPMLatestTag {
+		args = append(args, "--tag", distTag)
 	}
+	out, err := g.Cmd.Execute(packageDir, "npm", args...)
 	log.Info().Msg(out)
 	if err != nil {
 		// NPM returns the error message on STDOUT, so we need to check there for the error
@@ -92,11 +110,15 @@ func (g *Generator) PushPackage(packageDir string) error {
 }
 
 func (g *Generator) incrementPackageVersion(packageDir string) error {
-	currentV := g.Version
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return err
+	}
+	currentV := v.NPM()
 	newV := fmt.Sprintf("%s-%d", currentV, time.Now().Unix())
 
 	log.Info().Msgf("Incrementing version %s to %s", currentV, newV)
-	err := g.Cmd.ExecuteAndLog(packageDir, "npm", "version", newV)
+	err = g.Cmd.ExecuteAndLog(packageDir, "npm", "version", newV)
 	if err != nil {
 		return errors.Wrapf(err, "failed to increment version to %s", newV)
 	}
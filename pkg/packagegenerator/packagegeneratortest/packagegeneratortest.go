@@ -0,0 +1,91 @@
+// Package packagegeneratortest provides a fake openapi-generator and base generators for testing the language generators
+package packagegeneratortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/assets"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
+	"github.com/stretchr/testify/require"
+)
+
+// Defaults for the values base generators are created with
+const (
+	Version     = "1.0.0"
+	ServiceName = "TestService"
+	RepoOwner   = "owner"
+	RepoName    = "test-service"
+	SpecPath    = "/tmp/spec.json"
+)
+
+// Generator is a fake openapi-generator that records the generator config of its language and writes files into the
+// configured output
+type Generator struct {
+	Language string
+	// Files returns the content of the files to write for the generator config, keyed by their path relative to the
+	// output. Only the output directory is created if nil.
+	Files func(generator *openapitools.Generator) map[string]string
+	// Generator is the generator config of the language openapi-generator was last run with
+	Generator *openapitools.Generator
+}
+
+// Generate reads the generator config of the language from the config and writes its files into the output
+func (f *Generator) Generate(configPath string, _ []string, _ ...string) error {
+	cfg, err := openapitools.ReadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	f.Generator = cfg.GeneratorCLI.Generators[f.Language]
+	if err = os.MkdirAll(f.Generator.Output, 0700); err != nil {
+		return err
+	}
+	if f.Files == nil {
+		return nil
+	}
+	for name, content := range f.Files(f.Generator) {
+		path := filepath.Join(f.Generator.Output, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err = os.WriteFile(path, []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Options are the values a base generator is created with, the defaults are used for any left empty
+type Options struct {
+	Version  string
+	RepoName string
+	SpecPath string
+}
+
+// NewBaseGenerator changes to a fresh working directory, which openapi-generator configs are written to, and returns a
+// base generator for the language with its default config that generates with the returned fake openapi-generator
+func NewBaseGenerator(t *testing.T, language string, opts Options) (*packagegenerator.BaseGenerator, *Generator) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	if opts.Version == "" {
+		opts.Version = Version
+	}
+	if opts.RepoName == "" {
+		opts.RepoName = RepoName
+	}
+	if opts.SpecPath == "" {
+		opts.SpecPath = SpecPath
+	}
+
+	cfg, err := openapitools.GetConfigForLanguage(assets.DefaultConfigs(), language, nil)
+	require.NoError(t, err)
+	base, err := packagegenerator.NewBaseGenerator(opts.Version, ServiceName, RepoOwner, opts.RepoName, "token", "user", opts.SpecPath, "Client", "", cfg)
+	require.NoError(t, err)
+
+	fake := &Generator{Language: language}
+	base.OpenAPIGenerator = fake
+	return base, fake
+}
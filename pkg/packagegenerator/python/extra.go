@@ -0,0 +1,84 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+// extraFieldConfigKey is the environment variable for how the generated models handle fields not in the spec
+const extraFieldConfigKey = "PY_EXTRA_FIELD_CONFIG"
+
+// Extra field configs, matching the pydantic extra options
+const (
+	// ExtraFieldsAllow keeps fields not in the spec on the model
+	ExtraFieldsAllow = "allow"
+	// ExtraFieldsForbid fails validation of models with fields not in the spec
+	ExtraFieldsForbid = "forbid"
+	// ExtraFieldsIgnore drops fields not in the spec, the pydantic default
+	ExtraFieldsIgnore = "ignore"
+)
+
+// modelConfig is the start of the pydantic model config openapi-generator writes for each model
+const modelConfig = "    model_config = ConfigDict(\n"
+
+// pydanticModel is how openapi-generator declares each pydantic model class
+const pydanticModel = "(BaseModel):\n"
+
+func extraFieldsFromEnvironment() string {
+	extraFields := os.Getenv(extraFieldConfigKey)
+	if extraFields == "" {
+		return ExtraFieldsIgnore
+	}
+	return extraFields
+}
+
+func (g *Generator) validateExtraFields() error {
+	switch g.ExtraFields {
+	case ExtraFieldsAllow, ExtraFieldsForbid, ExtraFieldsIgnore:
+		return nil
+	default:
+		return errors.Errorf("unsupported %s %q, must be one of %s, %s or %s", extraFieldConfigKey, g.ExtraFields, ExtraFieldsAllow, ExtraFieldsForbid, ExtraFieldsIgnore)
+	}
+}
+
+// applyExtraFields sets the extra field config on each of the generated models. Ignore is the pydantic default, so the
+// models are left as generated. Any pydantic model without the model config fails, so that the config can't silently
+// stop being applied if openapi-generator changes what it generates.
+func (g *Generator) applyExtraFields(generatedDir string) error {
+	if g.ExtraFields == ExtraFieldsIgnore {
+		return nil
+	}
+
+	modelFiles, err := filepath.Glob(filepath.Join(generatedDir, g.GetPackageName(), "models", "*.py"))
+	if err != nil {
+		return errors.Wrap(err, "failed to find generated models")
+	}
+	replaced := 0
+	for _, modelFile := range modelFiles {
+		data, err := g.FileIO.Read(modelFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", modelFile)
+		}
+		// Enums and the package init aren't pydantic models
+		if !strings.Contains(string(data), pydanticModel) {
+			continue
+		}
+		count := strings.Count(string(data), modelConfig)
+		if count == 0 {
+			return errors.Errorf("failed to set extra field config in %s, no model config found", modelFile)
+		}
+		err = g.FileIO.ReplaceInFile(modelFile, modelConfig, fmt.Sprintf("%s        extra=%q,\n", modelConfig, g.ExtraFields))
+		if err != nil {
+			return errors.Wrapf(err, "failed to set extra field config in %s", modelFile)
+		}
+		replaced += count
+	}
+	log.Info().Msgf("%sSet extra fields to %s on %d models%s", utils.Cyan, g.ExtraFields, replaced, utils.Reset)
+	return nil
+}
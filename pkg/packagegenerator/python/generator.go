@@ -3,7 +3,9 @@ package python
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	gh "github.com/google/go-github/v47/github"
@@ -11,6 +13,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/git"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/scmClient/github"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
@@ -30,71 +33,123 @@ var (
 	reviewers = []string{"Reton2"}
 )
 
+// publishModeKey is the environment variable for where python packages are published
+const publishModeKey = "PYTHON_PUBLISH_MODE"
+
+// Publish modes, selecting where python packages are published
+const (
+	// PublishModeSchemas opens a pull request with the package in the pipeline schemas repository
+	PublishModeSchemas = "schemas"
+	// PublishModePyx builds the package with uv and publishes it to the pyx index
+	PublishModePyx = "pyx"
+	// PublishModeBoth publishes to both the pipeline schemas repository and the pyx index
+	PublishModeBoth = "both"
+)
+
 type Generator struct {
 	*packagegenerator.BaseGenerator
 	Git domain.Gitter
 	Scm domain.ScmClient
 	Uvc domain.UVClient
+
+	// PublishMode is where the package is published, schemas, pyx or both
+	PublishMode string
+	// ExtraFields is how the generated models handle fields not in the spec, allow, forbid or ignore
+	ExtraFields string
+	// PythonVersions are the comma separated python versions the pyx package supports
+	PythonVersions string
+	// Extras are the optional dependencies of the pyx package, as a JSON object of extra names to requirements
+	Extras string
+	// Indexes are the uv indexes of the pyx package, as a JSON array, defaulting to the pyx index
+	Indexes string
+	// SmokeTest installs the generated package into an isolated environment and imports every module before it's
+	// committed or published, by default only when it's published to pyx
+	SmokeTest bool
+	// TypeChecker is the type checker the smoke test runs on the generated package, none, mypy or pyright
+	TypeChecker string
+
+	// pyxDir is the uv project directory built for the pyx index, if the package is published there
+	pyxDir string
+
+	pythonVersions []string
+	extras         map[string][]string
+	indexes        []Index
 }
 
 func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
+	publishMode := os.Getenv(publishModeKey)
+	if publishMode == "" {
+		publishMode = PublishModeSchemas
+	}
 	return &Generator{
-		BaseGenerator: baseGenerator,
-		Git:           git.NewClient(),
-		Scm:           github.NewClient(baseGenerator.RepoOwner, PipelineSchemasName, baseGenerator.GitToken),
-		Uvc:           uv.NewClient(),
+		BaseGenerator:  baseGenerator,
+		Git:            git.NewClient(),
+		Scm:            github.NewClient(baseGenerator.RepoOwner, PipelineSchemasName, baseGenerator.GitToken),
+		Uvc:            uv.NewClient(),
+		PublishMode:    publishMode,
+		ExtraFields:    extraFieldsFromEnvironment(),
+		PythonVersions: pythonVersionsFromEnvironment(),
+		Extras:         os.Getenv(extrasKey),
+		Indexes:        os.Getenv(indexesKey),
+		SmokeTest:      smokeTestFromEnvironment(publishMode),
+		TypeChecker:    typeCheckerFromEnvironment(),
 	}
 }
 
-func (g *Generator) GeneratePackage(outputDir string) (string, error) {
-	g.setDynamicConfigVariables()
-
-	// For now we ignore the packageDir since this is purely for POC
-	// err := g.GeneratePyxPackage(outputDir)
-	// if err != nil {
-	// 	return "", err
-	// }
-
-	packageDir, err := g.GenerateSchemasPackage(outputDir)
-	if err != nil {
-		return "", err
+// GenerationOptions returns the publish mode, extra field config and pyproject options, which change the generated
+// package
+func (g *Generator) GenerationOptions() []string {
+	return []string{
+		"publish-mode=" + g.PublishMode,
+		"extra-fields=" + g.ExtraFields,
+		"python-versions=" + g.PythonVersions,
+		"extras=" + g.Extras,
+		"indexes=" + g.Indexes,
+		"smoke-test=" + strconv.FormatBool(g.SmokeTest),
+		"type-checker=" + g.TypeChecker,
 	}
-	return packageDir, nil
 }
 
-func (g *Generator) GeneratePyxPackage(outputDir string) error {
-	pyxDir, err := g.FileIO.MkdirAll(filepath.Join(outputDir, g.GetPackageName()), 0700)
+func (g *Generator) GeneratePackage(outputDir string) (string, error) {
+	generatedDir, err := g.PrepareGeneration(outputDir)
 	if err != nil {
-		return errors.Wrap(err, "failed to create package directory")
+		return "", err
 	}
 
-	packageDir, err := g.BaseGenerator.GeneratePackage(pyxDir, domain.Python)
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.Python)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
 
-	err = g.Uvc.GeneratePyProjectFile(pyxDir, g.GetPackageName(), g.Version)
+// PrepareGeneration clones the pipeline schemas repository on a new branch to generate into, or creates the uv
+// project directory if the package is only published to pyx
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	err := g.validatePublishMode()
 	if err != nil {
-		return errors.Wrap(err, "failed to create pyproject.toml file")
+		return "", err
 	}
-
-	err = g.Uvc.BuildProject(packageDir)
+	err = g.validateExtraFields()
 	if err != nil {
-		return errors.Wrap(err, "failed to build UV project")
+		return "", err
 	}
-
-	// Because this is running in parallel with schemas repo, for now the publish step will have to live in here
-	// When we move to pyx we can move this to the publish step of the pipeline
-	err = g.Uvc.PublishProject(packageDir, uvIndexName)
+	err = g.validateTypeChecker()
 	if err != nil {
-		return errors.Wrap(err, "failed to publish UV project")
+		return "", err
 	}
-	log.Info().Msgf("Published UV project from %s to index %s", packageDir, uvIndexName)
+	if g.needsUVProject() {
+		err = g.validatePyProject()
+		if err != nil {
+			return "", err
+		}
+	}
+	g.setDynamicConfigVariables()
 
-	return nil
-}
+	if !g.publishesToSchemas() {
+		return g.SetOutput(g.uvProjectDir(outputDir), domain.Python)
+	}
 
-func (g *Generator) GenerateSchemasPackage(outputDir string) (string, error) {
 	repoDir, err := g.Git.Clone(outputDir, PipelineSchemasURL)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to clone pipeline schemas")
@@ -105,24 +160,115 @@ func (g *Generator) GenerateSchemasPackage(outputDir string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "failed to checkout branch")
 	}
+	return g.SetOutput(repoDir, domain.Python)
+}
 
-	packageDir, err := g.BaseGenerator.GeneratePackage(repoDir, domain.Python)
+// CompleteGeneration sets the extra field config on the generated models and smoke tests them, then commits the package
+// to the pipeline schemas repository and builds the uv project for pyx, depending on the publish mode. The schemas
+// repository is returned if the package is published there.
+func (g *Generator) CompleteGeneration(outputDir, generatedDir string) (string, error) {
+	err := g.applyExtraFields(generatedDir)
 	if err != nil {
 		return "", err
 	}
 
-	readmePath := fmt.Sprintf("%s_README.md", g.GetPackageName())
-	err = g.Git.AddFiles(repoDir, g.GetPackageName(), readmePath)
+	if g.needsUVProject() {
+		projectDir := generatedDir
+		if g.publishesToSchemas() {
+			// The package is generated once, into the schemas repository, and copied into its own uv project
+			projectDir, err = g.copyToUVProject(generatedDir, g.uvProjectDir(outputDir))
+			if err != nil {
+				return "", err
+			}
+		}
+
+		err = g.writePyProject(projectDir)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create pyproject.toml file")
+		}
+
+		if g.SmokeTest {
+			err = g.smokeTest(projectDir)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		if g.publishesToPyx() {
+			err = g.Uvc.BuildProject(projectDir)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to build UV project")
+			}
+			g.pyxDir = projectDir
+		}
+	}
+
+	if !g.publishesToSchemas() {
+		return generatedDir, nil
+	}
+
+	readmePath := g.readmeFileName()
+	err = g.Git.AddFiles(generatedDir, g.GetPackageName(), readmePath)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to add package to Git")
 	}
 
-	err = g.Git.Commit(repoDir, fmt.Sprintf("chore(deps): upgrade %s package -> %s", g.GetPackageName(), g.Version))
+	err = g.Git.Commit(generatedDir, fmt.Sprintf("chore(deps): upgrade %s package -> %s", g.GetPackageName(), g.Version))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to commit package")
 	}
 
-	return packageDir, nil
+	return generatedDir, nil
+}
+
+// uvProjectDir returns the directory of the uv project built for pyx and the smoke test
+func (g *Generator) uvProjectDir(outputDir string) string {
+	return filepath.Join(outputDir, g.GetPackageName())
+}
+
+func (g *Generator) readmeFileName() string {
+	return fmt.Sprintf("%s_README.md", g.GetPackageName())
+}
+
+// copyToUVProject copies the generated module and readme into the uv project directory
+func (g *Generator) copyToUVProject(generatedDir, projectDir string) (string, error) {
+	err := g.FileIO.CopyDir(filepath.Join(generatedDir, g.GetPackageName()), filepath.Join(projectDir, g.GetPackageName()))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to copy package to uv project")
+	}
+	_, err = g.FileIO.Copy(filepath.Join(generatedDir, g.readmeFileName()), filepath.Join(projectDir, g.readmeFileName()))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to copy readme to uv project")
+	}
+	return projectDir, nil
+}
+
+func (g *Generator) validatePublishMode() error {
+	switch g.PublishMode {
+	case PublishModeSchemas, PublishModePyx, PublishModeBoth:
+		return nil
+	default:
+		return errors.Errorf("unsupported %s %q, must be one of %s, %s or %s", publishModeKey, g.PublishMode, PublishModeSchemas, PublishModePyx, PublishModeBoth)
+	}
+}
+
+func (g *Generator) publishesToSchemas() bool {
+	return g.PublishMode == PublishModeSchemas || g.PublishMode == PublishModeBoth
+}
+
+func (g *Generator) publishesToPyx() bool {
+	return g.PublishMode == PublishModePyx || g.PublishMode == PublishModeBoth
+}
+
+// needsUVProject returns whether the package is built into a uv project, to publish it to pyx or smoke test it
+func (g *Generator) needsUVProject() bool {
+	return g.publishesToPyx() || g.SmokeTest
+}
+
+// ResolveConfig returns the openapi-generator config with the python specific variables set
+func (g *Generator) ResolveConfig() *openapitools.Config {
+	g.setDynamicConfigVariables()
+	return g.Cfg
 }
 
 func (g *Generator) setDynamicConfigVariables() {
@@ -140,6 +286,22 @@ func (g *Generator) GetPackageName() string {
 }
 
 func (g *Generator) PushPackage(packageDir string) error {
+	if g.publishesToPyx() {
+		pyxDir := g.pyxDir
+		if pyxDir == "" {
+			pyxDir = packageDir
+		}
+		// uv skips the files that are already on the index, so publishing the same version again is a no-op
+		err := g.Uvc.PublishProject(pyxDir, uvIndexName)
+		if err != nil {
+			return errors.Wrap(err, "failed to publish UV project")
+		}
+		log.Info().Msgf("%sPublished %s %s to the %s index%s", utils.Green, g.GetPackageName(), g.Version, uvIndexName, utils.Reset)
+	}
+	if !g.publishesToSchemas() {
+		return nil
+	}
+
 	currentBranch, err := g.Git.GetCurrentBranch(packageDir)
 	if err != nil {
 		return errors.Wrap(err, "failed to get current branch")
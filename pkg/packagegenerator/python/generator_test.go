@@ -0,0 +1,225 @@
+//go:build unit
+
+package python_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/packagegeneratortest"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/python"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const petModel = `from pydantic import BaseModel, ConfigDict, StrictStr
+from typing import Any, ClassVar, Dict, List
+from typing_extensions import Self
+
+
+class Pet(BaseModel):
+    name: StrictStr
+    __properties: ClassVar[List[str]] = ["name"]
+
+    model_config = ConfigDict(
+        populate_by_name=True,
+        validate_assignment=True,
+        protected_namespaces=(),
+    )
+`
+
+const kindEnum = `from enum import Enum
+
+
+class Kind(str, Enum):
+    DOG = 'dog'
+`
+
+// generatedFiles returns the files openapi-generator generates for python with the given pet model
+func generatedFiles(model string) func(*openapitools.Generator) map[string]string {
+	return func(*openapitools.Generator) map[string]string {
+		return map[string]string{
+			"test_service/__init__.py":        "",
+			"test_service/models/__init__.py": "",
+			"test_service/models/kind.py":     kindEnum,
+			"test_service/models/pet.py":      model,
+			"test_service_README.md":          "# test_service",
+		}
+	}
+}
+
+func newGenerator(t *testing.T, publishMode string) (*python.Generator, *mocks.Gitter, *mocks.UVClient, string) {
+	base, fake := packagegeneratortest.NewBaseGenerator(t, domain.Python, packagegeneratortest.Options{})
+	fake.Files = generatedFiles(petModel)
+
+	repoDir := t.TempDir()
+	gitter := mocks.NewGitter(t)
+	gitter.On("Clone", mock.Anything, python.PipelineSchemasURL).Return(repoDir, nil).Maybe()
+	gitter.On("CheckoutBranch", repoDir, "update/test_service/1.0.0").Return(nil).Maybe()
+	gitter.On("AddFiles", repoDir, "test_service", "test_service_README.md").Return(nil).Maybe()
+	gitter.On("Commit", repoDir, mock.Anything).Return(nil).Maybe()
+
+	g := python.NewGenerator(base)
+	g.Git = gitter
+	g.Uvc = mocks.NewUVClient(t)
+	g.PublishMode = publishMode
+	g.SmokeTest = false
+	return g, gitter, g.Uvc.(*mocks.UVClient), repoDir
+}
+
+func TestGenerator_GeneratePackage(t *testing.T) {
+	testCases := []struct {
+		name         string
+		publishMode  string
+		expectSchema bool
+		expectPyx    bool
+		expectedErr  string
+	}{
+		{
+			name:         "Schemas repository",
+			publishMode:  python.PublishModeSchemas,
+			expectSchema: true,
+		},
+		{
+			name:        "Pyx index",
+			publishMode: python.PublishModePyx,
+			expectPyx:   true,
+		},
+		{
+			name:         "Both",
+			publishMode:  python.PublishModeBoth,
+			expectSchema: true,
+			expectPyx:    true,
+		},
+		{
+			name:        "Unsupported mode",
+			publishMode: "pypi",
+			expectedErr: `unsupported PYTHON_PUBLISH_MODE "pypi"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, gitter, uvc, repoDir := newGenerator(t, tt.publishMode)
+			outputDir := t.TempDir()
+			pyxDir := filepath.Join(outputDir, "test_service")
+			if tt.expectPyx {
+				uvc.On("BuildProject", pyxDir).Return(nil).Once()
+			}
+
+			packageDir, err := g.GeneratePackage(outputDir)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.expectSchema {
+				assert.Equal(t, repoDir, packageDir)
+				gitter.AssertCalled(t, "Commit", repoDir, "chore(deps): upgrade test_service package -> 1.0.0")
+			} else {
+				assert.Equal(t, pyxDir, packageDir)
+				gitter.AssertNotCalled(t, "Clone", mock.Anything, mock.Anything)
+			}
+			if tt.expectPyx {
+				assert.FileExists(t, filepath.Join(pyxDir, "test_service", "__init__.py"))
+				assert.FileExists(t, filepath.Join(pyxDir, "test_service_README.md"))
+				assert.FileExists(t, filepath.Join(pyxDir, "pyproject.toml"))
+			}
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_ExtraFields(t *testing.T) {
+	testCases := []struct {
+		name          string
+		extraFields   string
+		model         string
+		expectedModel string
+		expectedErr   string
+	}{
+		{
+			name:          "Ignore leaves the models as generated",
+			extraFields:   python.ExtraFieldsIgnore,
+			expectedModel: petModel,
+		},
+		{
+			name:          "Forbid",
+			extraFields:   python.ExtraFieldsForbid,
+			expectedModel: strings.Replace(petModel, "ConfigDict(\n", "ConfigDict(\n        extra=\"forbid\",\n", 1),
+		},
+		{
+			name:          "Allow",
+			extraFields:   python.ExtraFieldsAllow,
+			expectedModel: strings.Replace(petModel, "ConfigDict(\n", "ConfigDict(\n        extra=\"allow\",\n", 1),
+		},
+		{
+			name:        "Unsupported config",
+			extraFields: "strict",
+			expectedErr: `unsupported PY_EXTRA_FIELD_CONFIG "strict"`,
+		},
+		{
+			name:        "Model without a model config",
+			extraFields: python.ExtraFieldsForbid,
+			model:       strings.Replace(petModel, "model_config = ConfigDict(\n", "model_config = ConfigDict(populate_by_name=True,\n", 1),
+			expectedErr: "no model config found",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, repoDir := newGenerator(t, python.PublishModeSchemas)
+			g.ExtraFields = tt.extraFields
+			if tt.model != "" {
+				g.OpenAPIGenerator.(*packagegeneratortest.Generator).Files = generatedFiles(tt.model)
+			}
+
+			_, err := g.GeneratePackage(t.TempDir())
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			model, err := os.ReadFile(filepath.Join(repoDir, "test_service", "models", "pet.py"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedModel, string(model))
+			enum, err := os.ReadFile(filepath.Join(repoDir, "test_service", "models", "kind.py"))
+			require.NoError(t, err)
+			assert.Equal(t, kindEnum, string(enum))
+		})
+	}
+}
+
+func TestGenerator_PushPackage(t *testing.T) {
+	t.Run("Pyx only publishes to the index", func(t *testing.T) {
+		g, gitter, uvc, _ := newGenerator(t, python.PublishModePyx)
+		uvc.On("PublishProject", "/tmp/pyx", "pyx").Return(nil).Once()
+
+		require.NoError(t, g.PushPackage("/tmp/pyx"))
+		gitter.AssertNotCalled(t, "Push", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Both publishes the built project and opens a pull request", func(t *testing.T) {
+		g, gitter, uvc, repoDir := newGenerator(t, python.PublishModeBoth)
+		outputDir := t.TempDir()
+		pyxDir := filepath.Join(outputDir, "test_service")
+		uvc.On("BuildProject", pyxDir).Return(nil)
+		uvc.On("PublishProject", pyxDir, "pyx").Return(nil).Once()
+
+		gitter.On("GetCurrentBranch", repoDir).Return("update/test_service/1.0.0", nil)
+		gitter.On("Push", repoDir, "update/test_service/1.0.0").Return(assert.AnError)
+
+		packageDir, err := g.GeneratePackage(outputDir)
+		require.NoError(t, err)
+
+		err = g.PushPackage(packageDir)
+		assert.ErrorContains(t, err, "failed to Git push package")
+	})
+}
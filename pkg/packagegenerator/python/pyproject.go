@@ -0,0 +1,194 @@
+package python
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// pythonVersionsKey is the environment variable for the comma separated python versions the package supports
+	pythonVersionsKey = "PYTHON_VERSIONS"
+	// extrasKey is the environment variable for the optional dependencies of the package, as a JSON object of extra
+	// names to requirements
+	extrasKey = "PYTHON_EXTRAS"
+	// indexesKey is the environment variable for the uv indexes of the package, as a JSON array
+	indexesKey = "PYTHON_INDEXES"
+
+	pyProjectPath = "python/pyproject.toml"
+
+	defaultPythonVersions = "3.10,3.11,3.12,3.13"
+)
+
+var (
+	pythonVersionRegex = regexp.MustCompile(`^3\.(\d+)$`)
+	extraNameRegex     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	importRegex        = regexp.MustCompile(`(?m)^\s*(?:from|import)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+	// runtimeDependencies are the requirements for the third party modules the generated client can import, matching
+	// the requirements openapi-generator writes when it generates the supporting files
+	runtimeDependencies = map[string]string{
+		"aiohttp":           "aiohttp>=3.8.4",
+		"aiohttp_retry":     "aiohttp-retry>=2.8.3",
+		"Crypto":            "pycryptodome>=3.9.0",
+		"dateutil":          "python-dateutil>=2.8.2",
+		"httpx":             "httpx>=0.28.1",
+		"lazy_imports":      "lazy-imports>=1,<2",
+		"pem":               "pem>=19.3.0",
+		"pydantic":          "pydantic>=2",
+		"tornado":           "tornado>=4.2,<5",
+		"typing_extensions": "typing-extensions>=4.7.1",
+		"urllib3":           "urllib3>=2.1.0,<3.0.0",
+	}
+
+	defaultIndexes = []Index{
+		{
+			Name:       uvIndexName,
+			URL:        "https://api.pyx.dev/simple/mqube/main",
+			PublishURL: "https://api.pyx.dev/v1/upload/mqube/main",
+		},
+	}
+)
+
+// Index is a uv package index
+type Index struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	PublishURL string `json:"publish-url,omitempty"`
+}
+
+// PyProject is the data the pyproject.toml template is rendered with
+type PyProject struct {
+	Name    string
+	Version string
+	Readme  string
+	// PythonVersions are the minor python versions the package supports, in order
+	PythonVersions []string
+	// Dependencies are the requirements of the third party modules the generated client imports
+	Dependencies []string
+	// Extras are the optional dependencies, keyed by extra name
+	Extras  map[string][]string
+	Indexes []Index
+}
+
+// RequiresPython returns the python version specifier covering the supported versions
+func (p PyProject) RequiresPython() string {
+	lowest, highest := p.PythonVersions[0], p.PythonVersions[len(p.PythonVersions)-1]
+	return fmt.Sprintf(">=%s, <3.%d", lowest, minorVersion(highest)+1)
+}
+
+func pythonVersionsFromEnvironment() string {
+	pythonVersions := os.Getenv(pythonVersionsKey)
+	if pythonVersions == "" {
+		return defaultPythonVersions
+	}
+	return pythonVersions
+}
+
+// validatePyProject parses the pyproject options, which are only used when the package is built into a uv project
+func (g *Generator) validatePyProject() error {
+	var versions []string
+	for _, v := range strings.Split(g.PythonVersions, ",") {
+		v = strings.TrimSpace(v)
+		if !pythonVersionRegex.MatchString(v) {
+			return errors.Errorf("unsupported python version %q in %s, must be a python 3 minor version such as 3.12", v, pythonVersionsKey)
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		return minorVersion(a) - minorVersion(b)
+	})
+	g.pythonVersions = slices.Compact(versions)
+
+	g.extras = nil
+	if g.Extras != "" {
+		err := json.Unmarshal([]byte(g.Extras), &g.extras)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s", extrasKey)
+		}
+		for name := range g.extras {
+			if !extraNameRegex.MatchString(name) {
+				return errors.Errorf("invalid extra name %q in %s", name, extrasKey)
+			}
+		}
+	}
+
+	g.indexes = defaultIndexes
+	if g.Indexes != "" {
+		g.indexes = nil
+		err := json.Unmarshal([]byte(g.Indexes), &g.indexes)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s", indexesKey)
+		}
+	}
+	for _, index := range g.indexes {
+		if index.Name == "" || index.URL == "" {
+			return errors.Errorf("indexes in %s must have a name and url", indexesKey)
+		}
+	}
+	if g.publishesToPyx() && !slices.ContainsFunc(g.indexes, func(index Index) bool { return index.Name == uvIndexName && index.PublishURL != "" }) {
+		return errors.Errorf("%s must include the %s index with a publish-url", indexesKey, uvIndexName)
+	}
+	return nil
+}
+
+func minorVersion(v string) int {
+	minor, _ := strconv.Atoi(pythonVersionRegex.FindStringSubmatch(v)[1])
+	return minor
+}
+
+// writePyProject templates the pyproject.toml for the generated package into the uv project directory
+func (g *Generator) writePyProject(pyxDir string) error {
+	v, err := g.ParsedVersion()
+	if err != nil {
+		return err
+	}
+	dependencies, err := g.runtimeDependencies(pyxDir)
+	if err != nil {
+		return err
+	}
+
+	pyProject := PyProject{
+		Name:           g.GetPackageName(),
+		Version:        v.PEP440(),
+		Readme:         g.readmeFileName(),
+		PythonVersions: g.pythonVersions,
+		Dependencies:   dependencies,
+		Extras:         g.extras,
+		Indexes:        g.indexes,
+	}
+	return g.FileIO.TemplateFiles(g.Templates, pyxDir, pyProject, pyProjectPath)
+}
+
+// runtimeDependencies returns the requirements for the third party modules imported by the generated package
+func (g *Generator) runtimeDependencies(pyxDir string) ([]string, error) {
+	var dependencies []string
+	err := filepath.WalkDir(filepath.Join(pyxDir, g.GetPackageName()), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".py" {
+			return err
+		}
+		data, err := g.FileIO.Read(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range importRegex.FindAllStringSubmatch(string(data), -1) {
+			if dependency, ok := runtimeDependencies[match[1]]; ok && !slices.Contains(dependencies, dependency) {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read generated package imports")
+	}
+	slices.Sort(dependencies)
+	return dependencies, nil
+}
@@ -0,0 +1,183 @@
+//go:build unit
+
+package python_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/python"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/uv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const defaultPyProject = `[project]
+name = "test_service"
+version = "1.0.0"
+description = "test_service schema package generated from OpenAPI specification"
+readme = "test_service_README.md"
+classifiers = [
+  "Programming Language :: Python :: 3.10",
+  "Programming Language :: Python :: 3.11",
+  "Programming Language :: Python :: 3.12",
+  "Programming Language :: Python :: 3.13",
+  "Private :: pyx :: mqube",
+]
+requires-python = ">=3.10, <3.14"
+dependencies = [
+  "pydantic>=2",
+  "typing-extensions>=4.7.1",
+]
+
+[build-system]
+requires = ["uv_build>=0.9.3,<0.10.0"]
+build-backend = "uv_build"
+
+[[tool.uv.index]]
+name = "pyx"
+url = "https://api.pyx.dev/simple/mqube/main"
+publish-url = "https://api.pyx.dev/v1/upload/mqube/main"
+
+[tool.uv.build-backend]
+module-name = "test_service"
+module-root = ""
+`
+
+const configuredPyProject = `[project]
+name = "test_service"
+version = "1.0.0"
+description = "test_service schema package generated from OpenAPI specification"
+readme = "test_service_README.md"
+classifiers = [
+  "Programming Language :: Python :: 3.11",
+  "Programming Language :: Python :: 3.12",
+  "Private :: pyx :: mqube",
+]
+requires-python = ">=3.11, <3.13"
+dependencies = [
+  "pydantic>=2",
+  "typing-extensions>=4.7.1",
+]
+
+[project.optional-dependencies]
+docs = [
+  "mkdocs",
+]
+test = [
+  "pytest>=8",
+  "pytest-asyncio>=0.23,<1",
+]
+
+[build-system]
+requires = ["uv_build>=0.9.3,<0.10.0"]
+build-backend = "uv_build"
+
+[[tool.uv.index]]
+name = "pyx"
+url = "https://api.pyx.dev/simple/mqube/dev"
+publish-url = "https://api.pyx.dev/v1/upload/mqube/dev"
+
+[[tool.uv.index]]
+name = "internal"
+url = "https://pypi.example.com/simple"
+
+[tool.uv.build-backend]
+module-name = "test_service"
+module-root = ""
+`
+
+func TestGenerator_GeneratePackage_PyProject(t *testing.T) {
+	testCases := []struct {
+		name              string
+		pythonVersions    string
+		extras            string
+		indexes           string
+		expectedPyProject string
+		expectedErr       string
+	}{
+		{
+			name:              "Defaults",
+			pythonVersions:    "3.10,3.11,3.12,3.13",
+			expectedPyProject: defaultPyProject,
+		},
+		{
+			name:           "Configured versions, extras and indexes",
+			pythonVersions: "3.12, 3.11",
+			extras:         `{"test": ["pytest>=8", "pytest-asyncio>=0.23,<1"], "docs": ["mkdocs"]}`,
+			indexes: `[
+				{"name": "pyx", "url": "https://api.pyx.dev/simple/mqube/dev", "publish-url": "https://api.pyx.dev/v1/upload/mqube/dev"},
+				{"name": "internal", "url": "https://pypi.example.com/simple"}
+			]`,
+			expectedPyProject: configuredPyProject,
+		},
+		{
+			name:           "Unsupported python version",
+			pythonVersions: "3.12,2.7",
+			expectedErr:    `unsupported python version "2.7" in PYTHON_VERSIONS`,
+		},
+		{
+			name:           "Invalid extras",
+			pythonVersions: "3.12",
+			extras:         `["pytest"]`,
+			expectedErr:    "failed to parse PYTHON_EXTRAS",
+		},
+		{
+			name:           "Invalid extra name",
+			pythonVersions: "3.12",
+			extras:         `{"test.unit": ["pytest"]}`,
+			expectedErr:    `invalid extra name "test.unit" in PYTHON_EXTRAS`,
+		},
+		{
+			name:           "Indexes without pyx",
+			pythonVersions: "3.12",
+			indexes:        `[{"name": "internal", "url": "https://pypi.example.com/simple"}]`,
+			expectedErr:    "PYTHON_INDEXES must include the pyx index with a publish-url",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, uvc, _ := newGenerator(t, python.PublishModePyx)
+			g.PythonVersions = tt.pythonVersions
+			g.Extras = tt.extras
+			g.Indexes = tt.indexes
+			outputDir := t.TempDir()
+			pyxDir := filepath.Join(outputDir, "test_service")
+			uvc.On("BuildProject", pyxDir).Return(nil).Maybe()
+
+			_, err := g.GeneratePackage(outputDir)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			pyProject, err := os.ReadFile(filepath.Join(pyxDir, "pyproject.toml"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPyProject, string(pyProject))
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_UVBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping uv build test in short mode")
+	}
+	if _, err := exec.LookPath("uv"); err != nil {
+		t.Skip("Skipping uv build test as uv is not installed")
+	}
+
+	g, _, _, _ := newGenerator(t, python.PublishModePyx)
+	g.Uvc = uv.NewClient()
+	outputDir := t.TempDir()
+
+	packageDir, err := g.GeneratePackage(outputDir)
+	require.NoError(t, err)
+
+	wheels, err := filepath.Glob(filepath.Join(packageDir, "dist", "test_service-1.0.0-*.whl"))
+	require.NoError(t, err)
+	assert.Len(t, wheels, 1)
+}
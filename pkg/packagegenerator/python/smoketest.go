@@ -0,0 +1,80 @@
+package python
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+const (
+	// smokeTestKey is the environment variable to enable the smoke test of the generated package with true, or disable
+	// it with false
+	smokeTestKey = "PYTHON_SMOKE_TEST"
+	// typeCheckerKey is the environment variable for the type checker the smoke test runs
+	typeCheckerKey = "PYTHON_TYPE_CHECKER"
+)
+
+// Type checkers the smoke test can run on the generated package
+const (
+	TypeCheckerNone    = "none"
+	TypeCheckerMypy    = "mypy"
+	TypeCheckerPyright = "pyright"
+)
+
+// importModulesScript imports the package and every module in it
+const importModulesScript = `import importlib
+import pkgutil
+
+package = importlib.import_module(%q)
+for module in pkgutil.walk_packages(package.__path__, package.__name__ + "."):
+    importlib.import_module(module.name)
+`
+
+// smokeTestFromEnvironment returns whether the smoke test is enabled. Unless it's set, it's only enabled when the
+// package is published to pyx, as in schemas mode the package is otherwise generated without uv or network access.
+func smokeTestFromEnvironment(publishMode string) bool {
+	smokeTest := os.Getenv(smokeTestKey)
+	if smokeTest == "" {
+		return publishMode != PublishModeSchemas
+	}
+	return smokeTest != "false"
+}
+
+func typeCheckerFromEnvironment() string {
+	typeChecker := os.Getenv(typeCheckerKey)
+	if typeChecker == "" {
+		return TypeCheckerNone
+	}
+	return typeChecker
+}
+
+func (g *Generator) validateTypeChecker() error {
+	switch g.TypeChecker {
+	case TypeCheckerNone, TypeCheckerMypy, TypeCheckerPyright:
+		return nil
+	default:
+		return errors.Errorf("unsupported %s %q, must be one of %s, %s or %s", typeCheckerKey, g.TypeChecker, TypeCheckerNone, TypeCheckerMypy, TypeCheckerPyright)
+	}
+}
+
+// smokeTest installs the uv project into an isolated environment, imports every module of the generated package and
+// runs the type checker on it
+func (g *Generator) smokeTest(projectDir string) error {
+	log.Info().Msgf("%sSmoke testing %s%s", utils.Cyan, g.GetPackageName(), utils.Reset)
+	err := g.Uvc.RunIsolated(projectDir, []string{"."}, "python", "-c", fmt.Sprintf(importModulesScript, g.GetPackageName()))
+	if err != nil {
+		return errors.Wrap(err, "failed to import generated package")
+	}
+
+	if g.TypeChecker == TypeCheckerNone {
+		return nil
+	}
+	err = g.Uvc.RunIsolated(projectDir, []string{".", g.TypeChecker}, g.TypeChecker, g.GetPackageName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to type check generated package with %s", g.TypeChecker)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+//go:build unit
+
+package python_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/packagegeneratortest"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/python"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator_SmokeTest(t *testing.T) {
+	testCases := []struct {
+		name              string
+		publishMode       string
+		smokeTest         string
+		expectedSmokeTest bool
+	}{
+		{name: "Off by default"},
+		{name: "Off by default in schemas mode", publishMode: python.PublishModeSchemas},
+		{name: "On by default in pyx mode", publishMode: python.PublishModePyx, expectedSmokeTest: true},
+		{name: "On by default in both mode", publishMode: python.PublishModeBoth, expectedSmokeTest: true},
+		{name: "Enabled in schemas mode", publishMode: python.PublishModeSchemas, smokeTest: "true", expectedSmokeTest: true},
+		{name: "Disabled in pyx mode", publishMode: python.PublishModePyx, smokeTest: "false"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PYTHON_PUBLISH_MODE", tt.publishMode)
+			t.Setenv("PYTHON_SMOKE_TEST", tt.smokeTest)
+			base, _ := packagegeneratortest.NewBaseGenerator(t, domain.Python, packagegeneratortest.Options{})
+
+			g := python.NewGenerator(base)
+			assert.Equal(t, tt.expectedSmokeTest, g.SmokeTest)
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_SmokeTest(t *testing.T) {
+	testCases := []struct {
+		name         string
+		typeChecker  string
+		importErr    error
+		typeCheckErr error
+		expectedErr  string
+	}{
+		{
+			name:        "Imports",
+			typeChecker: python.TypeCheckerNone,
+		},
+		{
+			name:        "Imports and type checks with mypy",
+			typeChecker: python.TypeCheckerMypy,
+		},
+		{
+			name:        "Import failure",
+			typeChecker: python.TypeCheckerPyright,
+			importErr:   assert.AnError,
+			expectedErr: "failed to import generated package",
+		},
+		{
+			name:         "Type check failure",
+			typeChecker:  python.TypeCheckerPyright,
+			typeCheckErr: assert.AnError,
+			expectedErr:  "failed to type check generated package with pyright",
+		},
+		{
+			name:        "Unsupported type checker",
+			typeChecker: "pytype",
+			expectedErr: `unsupported PYTHON_TYPE_CHECKER "pytype"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g, gitter, uvc, repoDir := newGenerator(t, python.PublishModeSchemas)
+			g.SmokeTest = true
+			g.TypeChecker = tt.typeChecker
+			outputDir := t.TempDir()
+			projectDir := filepath.Join(outputDir, "test_service")
+
+			uvc.On("RunIsolated", projectDir, []string{"."}, "python", "-c", mock.MatchedBy(func(script string) bool {
+				return strings.Contains(script, `importlib.import_module("test_service")`)
+			})).Return(tt.importErr).Maybe()
+			uvc.On("RunIsolated", projectDir, []string{".", tt.typeChecker}, tt.typeChecker, "test_service").Return(tt.typeCheckErr).Maybe()
+
+			packageDir, err := g.GeneratePackage(outputDir)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				gitter.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, repoDir, packageDir)
+			assert.FileExists(t, filepath.Join(projectDir, "pyproject.toml"))
+			gitter.AssertCalled(t, "Commit", repoDir, mock.Anything)
+
+			if tt.typeChecker == python.TypeCheckerNone {
+				uvc.AssertNumberOfCalls(t, "RunIsolated", 1)
+			} else {
+				uvc.AssertNumberOfCalls(t, "RunIsolated", 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package rust
+
+import (
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+const blockingModule = `pub mod apis;
+`
+
+const blockingLib = `
+#[cfg(feature = "blocking")]
+pub mod blocking;
+`
+
+// generateBlockingClient generates the crate again with the blocking reqwest client, and adds its apis to the crate in
+// the blocking module behind the blocking feature. The models are shared with the async client.
+func (g *Generator) generateBlockingClient(generatedDir string) error {
+	log.Info().Msgf("%sGenerating blocking client%s", utils.Cyan, utils.Reset)
+	blockingDir, err := g.FileIO.MkTmpDir("rust-blocking")
+	if err != nil {
+		return errors.Wrap(err, "failed to create blocking client dir")
+	}
+	defer g.FileIO.DeferRemove(blockingDir)
+
+	generator := g.Cfg.GeneratorCLI.Generators[domain.Rust]
+	properties, output := maps.Clone(generator.AdditionalProperties), generator.Output
+	defer func() {
+		generator.AdditionalProperties, generator.Output = properties, output
+	}()
+	generator.AdditionalProperties = maps.Clone(properties)
+	generator.AdditionalProperties["supportAsync"] = false
+	// The middleware, mocks and top level client are only generated for async clients
+	for _, property := range []string{"supportMiddleware", "mockall", "topLevelApiClient"} {
+		generator.AdditionalProperties[property] = false
+	}
+
+	_, err = g.BaseGenerator.GeneratePackage(blockingDir, domain.Rust)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate blocking client")
+	}
+
+	moduleDir := filepath.Join(generatedDir, "src", "blocking")
+	err = g.FileIO.CopyDir(filepath.Join(blockingDir, "src", "apis"), filepath.Join(moduleDir, "apis"))
+	if err != nil {
+		return errors.Wrap(err, "failed to copy blocking client")
+	}
+	// The blocking apis refer to themselves through the crate root, which is now the blocking module
+	apis, err := filepath.Glob(filepath.Join(moduleDir, "apis", "*.rs"))
+	if err != nil {
+		return errors.Wrap(err, "failed to find blocking client apis")
+	}
+	for _, api := range apis {
+		for _, path := range []string{"crate::apis", "crate::{apis"} {
+			err = g.FileIO.ReplaceInFile(api, path, strings.Replace(path, "apis", "blocking::apis", 1))
+			if err != nil {
+				return errors.Wrapf(err, "failed to update %s", filepath.Base(api))
+			}
+		}
+	}
+	err = g.FileIO.Write(filepath.Join(moduleDir, "mod.rs"), []byte(blockingModule), 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to write blocking module")
+	}
+
+	libPath := filepath.Join(generatedDir, "src", "lib.rs")
+	lib, err := g.FileIO.Read(libPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read lib.rs")
+	}
+	err = g.FileIO.Write(libPath, []byte(strings.TrimRight(string(lib), "\n")+"\n"+blockingLib), 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to write lib.rs")
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package rust
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+)
+
+const (
+	// libraryKey is the environment variable for the openapi-generator rust library, overriding the config
+	libraryKey = "RUST_LIBRARY"
+	// clientsKey is the environment variable for the clients generated in the crate
+	clientsKey = "RUST_CLIENTS"
+	// tlsKey is the environment variable for the TLS implementation the crate enables by default
+	tlsKey = "RUST_TLS"
+)
+
+// Libraries supported by the openapi-generator rust generator
+const (
+	LibraryReqwest      = "reqwest"
+	LibraryReqwestTrait = "reqwest-trait"
+	LibraryHyper        = "hyper"
+	LibraryHyper0x      = "hyper0x"
+)
+
+// Clients the crate can provide
+const (
+	// ClientsAsync generates the async client, the default
+	ClientsAsync = "async"
+	// ClientsBlocking generates the blocking client
+	ClientsBlocking = "blocking"
+	// ClientsBoth generates the async client, and the blocking client in the blocking module behind the blocking feature
+	ClientsBoth = "both"
+)
+
+// TLS implementations the crate can enable by default, both are available as features
+const (
+	TLSNative = "native-tls"
+	TLSRustls = "rustls-tls"
+)
+
+var (
+	reqwestDependencyRegex = regexp.MustCompile(`(?m)^reqwest\s*=\s*(.+)$`)
+	dependencyVersionRegex = regexp.MustCompile(`version\s*=\s*"([^"]*)"`)
+	dependencyFeatureRegex = regexp.MustCompile(`features\s*=\s*\[([^\]]*)\]`)
+	featureRegex           = regexp.MustCompile(`(?ms)^([A-Za-z0-9_-]+)\s*=\s*\[(.*?)\]`)
+	featuresTableRegex     = regexp.MustCompile(`(?m)^\[features\]\s*$`)
+)
+
+func clientsFromEnvironment() string {
+	clients := os.Getenv(clientsKey)
+	if clients == "" {
+		return ClientsAsync
+	}
+	return clients
+}
+
+func tlsFromEnvironment() string {
+	tls := os.Getenv(tlsKey)
+	if tls == "" {
+		return TLSNative
+	}
+	return tls
+}
+
+// library returns the library the crate is generated with
+func (g *Generator) library() string {
+	if g.Library != "" {
+		return g.Library
+	}
+	library, _ := g.Cfg.GeneratorCLI.Generators[domain.Rust].AdditionalProperties["library"].(string)
+	return library
+}
+
+// usesReqwest returns whether the crate is generated with one of the reqwest libraries, whose TLS is selected by
+// features
+func (g *Generator) usesReqwest() bool {
+	return g.library() == LibraryReqwest || g.library() == LibraryReqwestTrait
+}
+
+func (g *Generator) validateClientOptions() error {
+	switch g.library() {
+	case LibraryReqwest, LibraryReqwestTrait, LibraryHyper, LibraryHyper0x:
+	default:
+		return errors.Errorf("unsupported rust library %q, must be one of %s, %s, %s or %s", g.library(), LibraryReqwest, LibraryReqwestTrait, LibraryHyper, LibraryHyper0x)
+	}
+
+	switch g.Clients {
+	case ClientsAsync:
+	case ClientsBlocking, ClientsBoth:
+		if g.library() != LibraryReqwest {
+			return errors.Errorf("%s %s requires the %s library, not %s", clientsKey, g.Clients, LibraryReqwest, g.library())
+		}
+	default:
+		return errors.Errorf("unsupported %s %q, must be one of %s, %s or %s", clientsKey, g.Clients, ClientsAsync, ClientsBlocking, ClientsBoth)
+	}
+
+	switch g.TLS {
+	case TLSNative, TLSRustls:
+	default:
+		return errors.Errorf("unsupported %s %q, must be %s or %s", tlsKey, g.TLS, TLSNative, TLSRustls)
+	}
+	return nil
+}
+
+// setClientConfigVariables sets the library and whether the generated client is async
+func (g *Generator) setClientConfigVariables() {
+	properties := g.Cfg.GeneratorCLI.Generators[domain.Rust].AdditionalProperties
+	if g.Library != "" {
+		properties["library"] = g.Library
+	}
+	if g.Clients == ClientsBlocking {
+		properties["supportAsync"] = false
+		// The middleware only supports async clients
+		properties["supportMiddleware"] = false
+	}
+}
+
+// writeFeatures rewrites the reqwest dependency and features of the crate so that the TLS implementation and the
+// blocking client are selected by features. Crates generated with the hyper libraries are left as generated.
+func (g *Generator) writeFeatures(generatedDir string) error {
+	if !g.usesReqwest() {
+		return nil
+	}
+
+	manifestPath := filepath.Join(generatedDir, "Cargo.toml")
+	manifest, err := g.FileIO.Read(manifestPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read crate manifest")
+	}
+	updated, err := g.features(string(manifest))
+	if err != nil {
+		return err
+	}
+	err = g.FileIO.Write(manifestPath, []byte(updated), 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to write crate manifest")
+	}
+	return nil
+}
+
+// features returns the manifest with reqwest's default features disabled and the TLS and blocking features added,
+// keeping any other features of the generated crate
+func (g *Generator) features(manifest string) (string, error) {
+	loc := reqwestDependencyRegex.FindStringSubmatchIndex(manifest)
+	if loc == nil {
+		return "", errors.New("crate manifest has no reqwest dependency")
+	}
+	dependency := manifest[loc[2]:loc[3]]
+
+	reqwestVersion := strings.Trim(strings.TrimSpace(dependency), `"`)
+	if match := dependencyVersionRegex.FindStringSubmatch(dependency); match != nil {
+		reqwestVersion = match[1]
+	}
+	var reqwestFeatures []string
+	if match := dependencyFeatureRegex.FindStringSubmatch(dependency); match != nil {
+		for _, feature := range quotedRegex.FindAllStringSubmatch(match[1], -1) {
+			if !isTLSFeature(feature[1]) && feature[1] != "blocking" {
+				reqwestFeatures = append(reqwestFeatures, feature[1])
+			}
+		}
+	}
+	if g.Clients == ClientsBlocking {
+		reqwestFeatures = append(reqwestFeatures, "blocking")
+	}
+	manifest = manifest[:loc[0]] + fmt.Sprintf("reqwest = { version = %q, default-features = false, features = %s }",
+		reqwestVersion, formatFeatureList(reqwestFeatures)) + manifest[loc[1]:]
+
+	// Keep the features of the generated crate, other than the ones replaced
+	features := [][2]string{{"default", formatFeatureList([]string{g.TLS})}}
+	start, end := len(manifest), len(manifest)
+	if loc := featuresTableRegex.FindStringIndex(manifest); loc != nil {
+		start, end = loc[0], len(manifest)
+		if next := tableHeaderRegex.FindStringIndex(manifest[loc[1]:]); next != nil {
+			end = loc[1] + next[0]
+		}
+		for _, feature := range featureRegex.FindAllStringSubmatch(manifest[loc[1]:end], -1) {
+			if feature[1] == "default" || feature[1] == "blocking" || isTLSFeature(feature[1]) {
+				continue
+			}
+			var enables []string
+			for _, enabled := range quotedRegex.FindAllStringSubmatch(feature[2], -1) {
+				enables = append(enables, enabled[1])
+			}
+			features = append(features, [2]string{feature[1], formatFeatureList(enables)})
+		}
+	}
+	features = append(features,
+		[2]string{TLSNative, formatFeatureList([]string{"reqwest/native-tls"})},
+		[2]string{TLSRustls, formatFeatureList([]string{"reqwest/rustls-tls"})},
+	)
+	if g.Clients == ClientsBoth {
+		features = append(features, [2]string{"blocking", formatFeatureList([]string{"reqwest/blocking"})})
+	}
+
+	var table strings.Builder
+	table.WriteString("[features]\n")
+	for _, feature := range features {
+		fmt.Fprintf(&table, "%s = %s\n", feature[0], feature[1])
+	}
+	if start == len(manifest) {
+		return strings.TrimRight(manifest, "\n") + "\n\n" + table.String(), nil
+	}
+	rest := manifest[end:]
+	if rest != "" {
+		table.WriteString("\n")
+	}
+	return manifest[:start] + table.String() + rest, nil
+}
+
+func isTLSFeature(feature string) bool {
+	return slices.Contains([]string{"default-tls", TLSNative, TLSRustls}, feature) ||
+		strings.HasPrefix(feature, TLSNative+"-") || strings.HasPrefix(feature, TLSRustls+"-")
+}
+
+func formatFeatureList(features []string) string {
+	quoted := make([]string, len(features))
+	for i, feature := range features {
+		quoted[i] = fmt.Sprintf("%q", feature)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,158 @@
+//go:build unit
+
+package rust_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/rust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GeneratePackage_Clients(t *testing.T) {
+	testCases := []struct {
+		name             string
+		library          string
+		clients          string
+		tls              string
+		expectedManifest string
+		expectedCheck    []any
+		expectBlocking   bool
+		expectedErr      string
+	}{
+		{
+			name:    "Async with native-tls",
+			clients: rust.ClientsAsync,
+			tls:     rust.TLSNative,
+			expectedManifest: `[package]
+name = "test-service"
+version = "1.0.0"
+edition = "2021"
+
+[dependencies]
+reqwest = { version = "^0.12", default-features = false, features = ["json", "multipart"] }
+
+[features]
+default = ["native-tls"]
+native-tls = ["reqwest/native-tls"]
+rustls-tls = ["reqwest/rustls-tls"]
+`,
+		},
+		{
+			name:    "Blocking with rustls",
+			library: rust.LibraryReqwest,
+			clients: rust.ClientsBlocking,
+			tls:     rust.TLSRustls,
+			expectedManifest: `[package]
+name = "test-service"
+version = "1.0.0"
+edition = "2021"
+
+[dependencies]
+reqwest = { version = "^0.12", default-features = false, features = ["json", "multipart", "blocking"] }
+
+[features]
+default = ["rustls-tls"]
+native-tls = ["reqwest/native-tls"]
+rustls-tls = ["reqwest/rustls-tls"]
+`,
+		},
+		{
+			name:    "Async and blocking",
+			library: rust.LibraryReqwest,
+			clients: rust.ClientsBoth,
+			tls:     rust.TLSNative,
+			expectedManifest: `[package]
+name = "test-service"
+version = "1.0.0"
+edition = "2021"
+
+[dependencies]
+reqwest = { version = "^0.12", default-features = false, features = ["json", "multipart"] }
+
+[features]
+default = ["native-tls"]
+native-tls = ["reqwest/native-tls"]
+rustls-tls = ["reqwest/rustls-tls"]
+blocking = ["reqwest/blocking"]
+`,
+			expectedCheck:  []any{"--features", "blocking"},
+			expectBlocking: true,
+		},
+		{
+			name:        "Blocking with reqwest-trait",
+			clients:     rust.ClientsBlocking,
+			tls:         rust.TLSNative,
+			expectedErr: "RUST_CLIENTS blocking requires the reqwest library, not reqwest-trait",
+		},
+		{
+			name:        "Unsupported library",
+			library:     "ureq",
+			clients:     rust.ClientsAsync,
+			tls:         rust.TLSNative,
+			expectedErr: `unsupported rust library "ureq"`,
+		},
+		{
+			name:        "Unsupported TLS",
+			clients:     rust.ClientsAsync,
+			tls:         "openssl",
+			expectedErr: `unsupported RUST_TLS "openssl"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			g, _ := newGenerator(t, repoDir)
+			g.Library, g.Clients, g.TLS = tt.library, tt.clients, tt.tls
+			cmd := mocks.NewCommandRunner(t)
+			cmd.On("Execute", append([]any{mock.Anything, "cargo", "check"}, tt.expectedCheck...)...).Return("", nil).Maybe()
+			g.Cmd = cmd
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			cmd.AssertNumberOfCalls(t, "Execute", 1)
+
+			manifest, err := os.ReadFile(filepath.Join(packageDir, "Cargo.toml"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedManifest, string(manifest))
+
+			properties := g.Cfg.GeneratorCLI.Generators[domain.Rust].AdditionalProperties
+			if tt.clients == rust.ClientsBlocking {
+				assert.Equal(t, false, properties["supportAsync"])
+				assert.Equal(t, false, properties["supportMiddleware"])
+			} else {
+				assert.NotContains(t, properties, "supportAsync")
+			}
+
+			lib, err := os.ReadFile(filepath.Join(packageDir, "src", "lib.rs"))
+			require.NoError(t, err)
+			if !tt.expectBlocking {
+				assert.Equal(t, "pub mod apis;\n", string(lib))
+				assert.NoDirExists(t, filepath.Join(packageDir, "src", "blocking"))
+				return
+			}
+			assert.Equal(t, "pub mod apis;\n\n#[cfg(feature = \"blocking\")]\npub mod blocking;\n", string(lib))
+
+			api, err := os.ReadFile(filepath.Join(packageDir, "src", "blocking", "apis", "pet_api.rs"))
+			require.NoError(t, err)
+			assert.Contains(t, string(api), "use crate::{blocking::apis::ResponseContent};")
+			assert.Contains(t, string(api), "-> crate::blocking::apis::ResponseContent")
+			assert.NotContains(t, string(api), "async fn")
+			configuration, err := os.ReadFile(filepath.Join(packageDir, "src", "blocking", "apis", "configuration.rs"))
+			require.NoError(t, err)
+			assert.Contains(t, string(configuration), "reqwest::blocking::Client")
+			assert.FileExists(t, filepath.Join(packageDir, "src", "blocking", "mod.rs"))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/git"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/scmClient/github"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
@@ -27,6 +28,15 @@ type Generator struct {
 	*packagegenerator.BaseGenerator
 	Git domain.Gitter
 	Scm domain.ScmClient
+
+	// Registry is the cargo registry the crate is published to, in addition to the packages repository, if set
+	Registry string
+	// Library is the openapi-generator rust library, overriding the config if set
+	Library string
+	// Clients are the clients generated in the crate, async, blocking or both
+	Clients string
+	// TLS is the TLS implementation the crate enables by default, native-tls or rustls-tls
+	TLS string
 }
 
 func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
@@ -34,10 +44,39 @@ func NewGenerator(baseGenerator *packagegenerator.BaseGenerator) *Generator {
 		BaseGenerator: baseGenerator,
 		Git:           git.NewClient(),
 		Scm:           github.NewClient(baseGenerator.RepoOwner, PushRepositoryName, baseGenerator.GitToken),
+		Registry:      registryFromEnvironment(),
+		Library:       os.Getenv(libraryKey),
+		Clients:       clientsFromEnvironment(),
+		TLS:           tlsFromEnvironment(),
 	}
 }
 
+// GenerationOptions returns the library, clients and TLS options, which change the generated crate, and the registry
+// it's published to, so that a newly configured registry isn't skipped for an unchanged crate
+func (g *Generator) GenerationOptions() []string {
+	return []string{"library=" + g.Library, "clients=" + g.Clients, "tls=" + g.TLS, "registry=" + g.Registry}
+}
+
 func (g *Generator) GeneratePackage(outputDir string) (string, error) {
+	generatedDir, err := g.PrepareGeneration(outputDir)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = g.BaseGenerator.GeneratePackage(generatedDir, domain.Rust)
+	if err != nil {
+		return "", err
+	}
+	return g.CompleteGeneration(outputDir, generatedDir)
+}
+
+// PrepareGeneration clones the packages repository and creates a fresh package directory on a new branch to generate
+// into
+func (g *Generator) PrepareGeneration(outputDir string) (string, error) {
+	err := g.validateClientOptions()
+	if err != nil {
+		return "", err
+	}
 	g.setDynamicConfigVariables()
 
 	repoDir, err := g.Git.Clone(outputDir, PushRepositoryURL)
@@ -56,18 +95,45 @@ func (g *Generator) GeneratePackage(outputDir string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create fresh package dir")
 	}
+	return g.SetOutput(packageDir, domain.Rust)
+}
+
+// CompleteGeneration writes the VERSION file, adds the blocking client and features, registers the crate in the
+// repository workspace, checks it compiles and commits the generated package
+func (g *Generator) CompleteGeneration(_, generatedDir string) (string, error) {
+	repoDir := filepath.Dir(generatedDir)
 
-	_, err = g.BaseGenerator.GeneratePackage(packageDir, domain.Rust)
+	err := g.FileIO.Write(filepath.Join(generatedDir, "VERSION"), []byte(g.Version), 0700)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write VERSION file")
+	}
+
+	if g.Clients == ClientsBoth {
+		err = g.generateBlockingClient(generatedDir)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = g.writeFeatures(generatedDir)
 	if err != nil {
 		return "", err
 	}
 
-	err = g.FileIO.Write(filepath.Join(packageDir, "VERSION"), []byte(g.Version), 0700)
+	files := []string{generatedDir}
+	changed, err := g.registerWorkspaceMember(repoDir, filepath.Base(generatedDir))
 	if err != nil {
-		return "", errors.Wrap(err, "failed to write VERSION file")
+		return "", errors.Wrap(err, "failed to register crate in workspace")
+	}
+	if changed {
+		files = append(files, filepath.Join(repoDir, workspaceManifest))
 	}
 
-	err = g.Git.AddFiles(repoDir, packageDir)
+	err = g.checkPackage(generatedDir)
+	if err != nil {
+		return "", err
+	}
+
+	err = g.Git.AddFiles(repoDir, files...)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to add files to Git")
 	}
@@ -77,19 +143,36 @@ func (g *Generator) GeneratePackage(outputDir string) (string, error) {
 		return "", errors.Wrap(err, "failed to commit package")
 	}
 
-	return packageDir, nil
+	return generatedDir, nil
+}
+
+// ResolveConfig returns the openapi-generator config with the rust specific variables set
+func (g *Generator) ResolveConfig() *openapitools.Config {
+	g.setDynamicConfigVariables()
+	return g.Cfg
 }
 
 func (g *Generator) setDynamicConfigVariables() {
 	g.Cfg.GeneratorCLI.Generators[domain.Rust].AdditionalProperties["packageName"] = g.GetPackageName()
-	g.Cfg.GeneratorCLI.Generators[domain.Rust].AdditionalProperties["packageVersion"] = g.Version
+	g.setClientConfigVariables()
 }
 
 func (g *Generator) GetPackageName() string {
 	return g.RepoName
 }
 
+// PushPackage pushes the package and opens a pull request for it, then publishes the crate to the registry if there is
+// one. The crate is only published once the pull request is open, as published versions can't be replaced.
 func (g *Generator) PushPackage(packageDir string) error {
+	var crateVersion string
+	if g.Registry != "" {
+		var err error
+		crateVersion, err = g.crateVersion(packageDir)
+		if err != nil {
+			return errors.Wrap(err, "failed to publish crate")
+		}
+	}
+
 	currentBranch, err := g.Git.GetCurrentBranch(packageDir)
 	if err != nil {
 		return errors.Wrap(err, "failed to get current branch")
@@ -109,6 +192,29 @@ func (g *Generator) PushPackage(packageDir string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to create pull request")
 	}
+
+	if g.Registry != "" {
+		err = g.publishCrate(packageDir, crateVersion)
+		if err != nil {
+			return errors.Wrap(err, "failed to publish crate")
+		}
+	}
+	return nil
+}
+
+// checkPackage runs cargo check on the generated crate so that code that doesn't compile is never committed. It isn't
+// run with --offline, as the pipeline starts without a registry cache to resolve the generated dependencies from, so
+// it fetches them unless CARGO_NET_OFFLINE is set.
+func (g *Generator) checkPackage(generatedDir string) error {
+	log.Info().Msgf("%sChecking generated crate%s", utils.Cyan, utils.Reset)
+	args := []string{"check"}
+	if g.Clients == ClientsBoth {
+		args = append(args, "--features", "blocking")
+	}
+	out, err := g.Cmd.Execute(generatedDir, "cargo", args...)
+	if err != nil {
+		return errors.Wrapf(err, "cargo check failed on the generated package:\n%s", out)
+	}
 	return nil
 }
 
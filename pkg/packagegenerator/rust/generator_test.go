@@ -0,0 +1,305 @@
+//go:build unit
+
+package rust_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/openapitools"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/packagegeneratortest"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/rust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const crateManifest = `[package]
+name = "test-service"
+version = "1.0.0"
+edition = "2021"
+
+[dependencies]
+reqwest = { version = "^0.12", default-features = false, features = ["json", "multipart"] }
+
+[features]
+default = ["native-tls"]
+native-tls = ["reqwest/native-tls"]
+rustls-tls = ["reqwest/rustls-tls"]
+`
+
+// generatedFiles returns a crate with the layout of the openapi-generator reqwest library, depending on the stub reqwest
+// crate in the vendored registry
+func generatedFiles(generator *openapitools.Generator) map[string]string {
+	client, async := "reqwest::Client", "async "
+	if generator.AdditionalProperties["supportAsync"] == false {
+		client, async = "reqwest::blocking::Client", ""
+	}
+
+	return map[string]string{
+		"Cargo.toml":                crateManifest,
+		"src/lib.rs":                "pub mod apis;\n",
+		"src/apis/mod.rs":           "pub mod configuration;\npub mod pet_api;\n\npub struct ResponseContent {\n    pub status: u16,\n}\n",
+		"src/apis/configuration.rs": "pub struct Configuration {\n    pub client: " + client + ",\n}\n",
+		"src/apis/pet_api.rs": "use crate::{apis::ResponseContent};\nuse super::configuration;\n\n" +
+			"pub " + async + "fn get_pet(_configuration: &configuration::Configuration) -> crate::apis::ResponseContent {\n" +
+			"    ResponseContent { status: 200 }\n}\n",
+	}
+}
+
+func newGenerator(t *testing.T, repoDir string) (*rust.Generator, *mocks.Gitter) {
+	base, fake := packagegeneratortest.NewBaseGenerator(t, domain.Rust, packagegeneratortest.Options{})
+	fake.Files = generatedFiles
+
+	gitter := mocks.NewGitter(t)
+	gitter.On("Clone", mock.Anything, rust.PushRepositoryURL).Return(repoDir, nil).Maybe()
+	gitter.On("CheckoutBranch", repoDir, "update/test-service/1.0.0").Return(nil).Maybe()
+	gitter.On("AddFiles", mock.Anything, mock.Anything).Return(nil).Maybe()
+	gitter.On("AddFiles", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	gitter.On("Commit", repoDir, "chore(deps): upgrade test-service module -> 1.0.0").Return(nil).Maybe()
+
+	g := rust.NewGenerator(base)
+	g.Git = gitter
+	return g, gitter
+}
+
+func newCommandRunner(t *testing.T, checkOutput string, checkErr error) *mocks.CommandRunner {
+	cmd := mocks.NewCommandRunner(t)
+	cmd.On("Execute", mock.Anything, "cargo", "check").Return(checkOutput, checkErr)
+	return cmd
+}
+
+func TestGenerator_GeneratePackage_Workspace(t *testing.T) {
+	testCases := []struct {
+		name             string
+		manifest         string
+		crates           []string
+		expectedManifest string
+	}{
+		{
+			name: "No workspace manifest",
+			expectedManifest: `[workspace]
+resolver = "2"
+members = [
+    "test-service",
+]
+`,
+		},
+		{
+			name:   "No workspace manifest with existing crates",
+			crates: []string{"zebra-service", "another-service"},
+			expectedManifest: `[workspace]
+resolver = "2"
+members = [
+    "another-service",
+    "test-service",
+    "zebra-service",
+]
+`,
+		},
+		{
+			name: "Manifest without a workspace with existing crates",
+			manifest: `[patch.crates-io]
+reqwest = { path = "vendor/reqwest" }
+`,
+			crates: []string{"another-service"},
+			expectedManifest: `[patch.crates-io]
+reqwest = { path = "vendor/reqwest" }
+
+[workspace]
+resolver = "2"
+members = [
+    "another-service",
+    "test-service",
+]
+`,
+		},
+		{
+			name: "Existing crates aren't added to an existing workspace",
+			manifest: `[workspace]
+members = ["another-service"]
+`,
+			crates: []string{"another-service", "unlisted-service"},
+			expectedManifest: `[workspace]
+members = [
+    "another-service",
+    "test-service",
+]
+`,
+		},
+		{
+			name: "New member",
+			manifest: `[workspace]
+resolver = "2"
+members = [
+    "other-service",
+]
+
+[workspace.dependencies]
+serde = "1"
+`,
+			expectedManifest: `[workspace]
+resolver = "2"
+members = [
+    "other-service",
+    "test-service",
+]
+
+[workspace.dependencies]
+serde = "1"
+`,
+		},
+		{
+			name: "Inline members",
+			manifest: `[workspace]
+members = ["other-service"]
+`,
+			expectedManifest: `[workspace]
+members = [
+    "other-service",
+    "test-service",
+]
+`,
+		},
+		{
+			name: "Workspace without members",
+			manifest: `[workspace]
+resolver = "2"
+`,
+			expectedManifest: `[workspace]
+members = [
+    "test-service",
+]
+resolver = "2"
+`,
+		},
+		{
+			name: "Existing member",
+			manifest: `[workspace]
+members = [
+    "test-service",
+]
+`,
+		},
+		{
+			name: "Member matched by a glob",
+			manifest: `[workspace]
+members = ["*-service"]
+`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			manifestPath := filepath.Join(repoDir, "Cargo.toml")
+			if tt.manifest != "" {
+				require.NoError(t, os.WriteFile(manifestPath, []byte(tt.manifest), 0600))
+			}
+			for _, crate := range tt.crates {
+				require.NoError(t, os.MkdirAll(filepath.Join(repoDir, crate), 0700))
+				require.NoError(t, os.WriteFile(filepath.Join(repoDir, crate, "Cargo.toml"), []byte(crateManifest), 0600))
+			}
+			// Directories without a crate, such as .git, aren't members
+			require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0700))
+			require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "docs"), 0700))
+			g, gitter := newGenerator(t, repoDir)
+			g.Cmd = newCommandRunner(t, "", nil)
+
+			packageDir, err := g.GeneratePackage(t.TempDir())
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(repoDir, "test-service"), packageDir)
+
+			manifest, err := os.ReadFile(manifestPath)
+			require.NoError(t, err)
+			if tt.expectedManifest == "" {
+				assert.Equal(t, tt.manifest, string(manifest))
+				gitter.AssertCalled(t, "AddFiles", repoDir, packageDir)
+			} else {
+				assert.Equal(t, tt.expectedManifest, string(manifest))
+				gitter.AssertCalled(t, "AddFiles", repoDir, packageDir, manifestPath)
+			}
+			gitter.AssertCalled(t, "Commit", repoDir, mock.Anything)
+		})
+	}
+}
+
+func TestGenerator_GeneratePackage_CheckFailure(t *testing.T) {
+	g, gitter := newGenerator(t, t.TempDir())
+	g.Cmd = newCommandRunner(t, "error[E0425]: cannot find function `stub`", assert.AnError)
+
+	_, err := g.GeneratePackage(t.TempDir())
+	assert.ErrorContains(t, err, "cargo check failed on the generated package")
+	assert.ErrorContains(t, err, "cannot find function `stub`")
+	gitter.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
+
+// newVendoredRepo returns a packages repository whose crates.io dependencies are replaced by the vendored registry
+func newVendoredRepo(t *testing.T) string {
+	vendorDir, err := filepath.Abs(filepath.Join("testdata", "vendor"))
+	require.NoError(t, err)
+	repoDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".cargo"), 0700))
+	cargoConfig := `[source.crates-io]
+replace-with = "vendored-sources"
+
+[source.vendored-sources]
+directory = "` + filepath.ToSlash(vendorDir) + `"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".cargo", "config.toml"), []byte(cargoConfig), 0600))
+	t.Setenv("CARGO_TARGET_DIR", t.TempDir())
+	return repoDir
+}
+
+func TestGenerator_GeneratePackage_CargoCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping cargo check test in short mode")
+	}
+	if _, err := exec.LookPath("cargo"); err != nil {
+		t.Skip("Skipping cargo check test as cargo is not installed")
+	}
+
+	testCases := []struct {
+		name    string
+		library string
+		clients string
+		tls     string
+	}{
+		{
+			name:    "Async",
+			clients: rust.ClientsAsync,
+			tls:     rust.TLSNative,
+		},
+		{
+			name:    "Blocking with rustls",
+			library: rust.LibraryReqwest,
+			clients: rust.ClientsBlocking,
+			tls:     rust.TLSRustls,
+		},
+		{
+			name:    "Async and blocking",
+			library: rust.LibraryReqwest,
+			clients: rust.ClientsBoth,
+			tls:     rust.TLSNative,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := newVendoredRepo(t)
+			t.Setenv("CARGO_NET_OFFLINE", "true")
+
+			g, _ := newGenerator(t, repoDir)
+			g.Cmd = commandrunner.NewCommandRunner()
+			g.Library, g.Clients, g.TLS = tt.library, tt.clients, tt.tls
+
+			_, err := g.GeneratePackage(t.TempDir())
+			require.NoError(t, err)
+		})
+	}
+}
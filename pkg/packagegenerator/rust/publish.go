@@ -0,0 +1,60 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
+)
+
+// registryKey is the environment variable for the name of the cargo registry to publish crates to, configured with
+// cargo's CARGO_REGISTRIES_<NAME>_INDEX and CARGO_REGISTRIES_<NAME>_TOKEN
+const registryKey = "RUST_REGISTRY"
+
+var manifestVersionRegex = regexp.MustCompile(`(?m)^version\s*=\s*"([^"]*)"`)
+
+func registryFromEnvironment() string {
+	return os.Getenv(registryKey)
+}
+
+// crateVersion returns the version of the crate in its VERSION file, checking it matches the crate manifest
+func (g *Generator) crateVersion(packageDir string) (string, error) {
+	data, err := g.FileIO.Read(filepath.Join(packageDir, "VERSION"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read VERSION file")
+	}
+	v, err := version.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return "", err
+	}
+
+	manifest, err := g.FileIO.Read(filepath.Join(packageDir, "Cargo.toml"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read crate manifest")
+	}
+	match := manifestVersionRegex.FindSubmatch(manifest)
+	if match == nil || string(match[1]) != v.Cargo() {
+		return "", errors.Errorf("crate manifest version doesn't match the VERSION file %s", v.Cargo())
+	}
+	return v.Cargo(), nil
+}
+
+// publishCrate publishes the crate to the registry
+func (g *Generator) publishCrate(packageDir, crateVersion string) error {
+	out, err := g.Cmd.Execute(packageDir, "cargo", "publish", "--registry", g.Registry)
+	if err != nil {
+		// Publishing the same version again is a no-op, so that re-running a pipeline doesn't fail
+		if strings.Contains(out, "already exists") || strings.Contains(out, "already uploaded") {
+			log.Info().Msgf("%s%s %s is already published to %s%s", utils.Yellow, g.GetPackageName(), crateVersion, g.Registry, utils.Reset)
+			return nil
+		}
+		return errors.Wrapf(err, "cargo publish failed:\n%s", out)
+	}
+	log.Info().Msgf("%sPublished %s %s to %s%s", utils.Green, g.GetPackageName(), crateVersion, g.Registry, utils.Reset)
+	return nil
+}
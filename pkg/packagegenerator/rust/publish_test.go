@@ -0,0 +1,189 @@
+//go:build unit
+
+package rust_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	gh "github.com/google/go-github/v47/github"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain/mocks"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/packagegenerator/rust"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// localRegistry is a cargo registry with a sparse index served from a local directory, which crates are published to
+type localRegistry struct {
+	*httptest.Server
+	indexDir string
+
+	mu        sync.Mutex
+	published map[string]bool
+}
+
+func newLocalRegistry(t *testing.T) *localRegistry {
+	r := &localRegistry{indexDir: t.TempDir(), published: make(map[string]bool)}
+	mux := http.NewServeMux()
+	mux.Handle("/index/", http.StripPrefix("/index/", http.FileServer(http.Dir(r.indexDir))))
+	mux.HandleFunc("PUT /api/v1/crates/new", r.publish)
+	r.Server = httptest.NewServer(mux)
+	t.Cleanup(r.Close)
+
+	config := fmt.Sprintf(`{"dl": "%s/dl", "api": "%s"}`, r.URL, r.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(r.indexDir, "config.json"), []byte(config), 0600))
+	return r
+}
+
+// publish handles cargo's publish request, a length prefixed JSON metadata object followed by the length prefixed
+// crate, adding the crate to the index
+func (r *localRegistry) publish(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	metadataLen := binary.LittleEndian.Uint32(body)
+	var metadata struct {
+		Name string `json:"name"`
+		Vers string `json:"vers"`
+	}
+	if err = json.Unmarshal(body[4:4+metadataLen], &metadata); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	crate := body[8+metadataLen:]
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := metadata.Name + "@" + metadata.Vers
+	if r.published[key] {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"errors": [{"detail": "crate version %s is already uploaded"}]}`, metadata.Vers)
+		return
+	}
+
+	sum := sha256.Sum256(crate)
+	entry, _ := json.Marshal(map[string]any{
+		"name": metadata.Name, "vers": metadata.Vers, "deps": []any{}, "cksum": hex.EncodeToString(sum[:]),
+		"features": map[string]any{}, "yanked": false,
+	})
+	entryPath := filepath.Join(r.indexDir, metadata.Name[:2], metadata.Name[2:4], metadata.Name)
+	if err = os.MkdirAll(filepath.Dir(entryPath), 0700); err == nil {
+		err = os.WriteFile(entryPath, append(entry, '\n'), 0600)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	r.published[key] = true
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"warnings": {"invalid_categories": [], "invalid_badges": [], "other": []}}`)
+}
+
+func TestGenerator_PushPackage_Registry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping cargo publish test in short mode")
+	}
+	if _, err := exec.LookPath("cargo"); err != nil {
+		t.Skip("Skipping cargo publish test as cargo is not installed")
+	}
+
+	registry := newLocalRegistry(t)
+	repoDir := newVendoredRepo(t)
+	t.Setenv("CARGO_REGISTRIES_LOCAL_INDEX", "sparse+"+registry.URL+"/index/")
+	t.Setenv("CARGO_REGISTRIES_LOCAL_TOKEN", "token")
+
+	g, gitter := newGenerator(t, repoDir)
+	g.Cmd = commandrunner.NewCommandRunner()
+	g.Registry = "local"
+	expectPullRequest(t, g, gitter)
+
+	packageDir, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+
+	err = g.PushPackage(packageDir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(registry.indexDir, "te", "st", "test-service"))
+
+	// Publishing the same version again is a no-op
+	err = g.PushPackage(packageDir)
+	assert.NoError(t, err)
+}
+
+// expectPullRequest expects the package to be pushed and a pull request to be opened for it
+func expectPullRequest(t *testing.T, g *rust.Generator, gitter *mocks.Gitter) *mocks.ScmClient {
+	gitter.On("GetCurrentBranch", mock.Anything).Return("update/test-service/1.0.0", nil)
+	gitter.On("Push", mock.Anything, "update/test-service/1.0.0").Return(nil)
+	gitter.On("GetDefaultBranchName", mock.Anything).Return("origin/main", nil)
+	scm := mocks.NewScmClient(t)
+	scm.On("CreatePullRequest", mock.Anything, mock.Anything).Return(&gh.PullRequest{Number: utils.NewPtr(7)}, nil).Maybe()
+	scm.On("AddLabels", mock.Anything, []string{"updatebot"}, 7).Return(nil, nil).Maybe()
+	g.Scm = scm
+	return scm
+}
+
+func TestGenerator_PushPackage_PublishesAfterPullRequest(t *testing.T) {
+	t.Run("Publishes once the pull request is open", func(t *testing.T) {
+		g, gitter := newGenerator(t, t.TempDir())
+		cmd := newCommandRunner(t, "", nil)
+		g.Cmd = cmd
+		g.Registry = "local"
+		scm := expectPullRequest(t, g, gitter)
+
+		packageDir, err := g.GeneratePackage(t.TempDir())
+		require.NoError(t, err)
+
+		cmd.On("Execute", packageDir, "cargo", "publish", "--registry", "local").Return("", nil).Once().
+			Run(func(mock.Arguments) {
+				scm.AssertCalled(t, "CreatePullRequest", mock.Anything, mock.Anything)
+			})
+		err = g.PushPackage(packageDir)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Doesn't publish if the pull request fails", func(t *testing.T) {
+		g, gitter := newGenerator(t, t.TempDir())
+		cmd := newCommandRunner(t, "", nil)
+		g.Cmd = cmd
+		g.Registry = "local"
+		gitter.On("GetCurrentBranch", mock.Anything).Return("update/test-service/1.0.0", nil)
+		gitter.On("Push", mock.Anything, "update/test-service/1.0.0").Return(assert.AnError)
+
+		packageDir, err := g.GeneratePackage(t.TempDir())
+		require.NoError(t, err)
+
+		err = g.PushPackage(packageDir)
+		assert.ErrorContains(t, err, "failed to Git push package")
+		cmd.AssertNotCalled(t, "Execute", mock.Anything, "cargo", "publish", "--registry", "local")
+	})
+}
+
+func TestGenerator_PushPackage_RegistryVersionMismatch(t *testing.T) {
+	repoDir := t.TempDir()
+	g, _ := newGenerator(t, repoDir)
+	g.Cmd = newCommandRunner(t, "", nil)
+	g.Registry = "local"
+
+	packageDir, err := g.GeneratePackage(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(packageDir, "VERSION"), []byte("1.1.0"), 0600))
+
+	err = g.PushPackage(packageDir)
+	assert.ErrorContains(t, err, "crate manifest version doesn't match the VERSION file 1.1.0")
+}
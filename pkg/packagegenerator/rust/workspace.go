@@ -0,0 +1,139 @@
+package rust
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/utils"
+)
+
+const workspaceManifest = "Cargo.toml"
+
+var (
+	tableHeaderRegex = regexp.MustCompile(`(?m)^\s*\[`)
+	membersRegex     = regexp.MustCompile(`(?ms)^members\s*=\s*\[(.*?)\]`)
+	quotedRegex      = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// registerWorkspaceMember adds the crate to the members of the workspace in the root Cargo.toml of the repository,
+// creating the workspace with every crate in the repository if there isn't one. Returns whether the manifest changed.
+func (g *Generator) registerWorkspaceMember(repoDir, member string) (bool, error) {
+	manifestPath := filepath.Join(repoDir, workspaceManifest)
+	exists, err := g.FileIO.Exists(manifestPath)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check for workspace manifest")
+	}
+
+	var manifest string
+	if exists {
+		data, err := g.FileIO.Read(manifestPath)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to read workspace manifest")
+		}
+		manifest = string(data)
+	}
+
+	members := []string{member}
+	if !strings.Contains(manifest, "[workspace]") {
+		// Once the workspace exists, the crates already in the repository believe they're in it, so they're members too
+		members, err = g.repositoryCrates(repoDir, member)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	updated, changed := manifest, false
+	for _, m := range members {
+		var added bool
+		updated, added = addWorkspaceMember(updated, m)
+		changed = changed || added
+	}
+	if !changed {
+		return false, nil
+	}
+	err = g.FileIO.Write(manifestPath, []byte(updated), 0644)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to write workspace manifest")
+	}
+	log.Info().Msgf("%sAdded %s to the workspace members%s", utils.Cyan, strings.Join(members, ", "), utils.Reset)
+	return true, nil
+}
+
+// repositoryCrates returns the directories in the root of the repository that contain a crate, including the member,
+// sorted by name
+func (g *Generator) repositoryCrates(repoDir, member string) ([]string, error) {
+	entries, err := os.ReadDir(repoDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read repository directory")
+	}
+
+	crates := []string{member}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == member || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		exists, err := g.FileIO.Exists(filepath.Join(repoDir, entry.Name(), workspaceManifest))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check for crate manifest")
+		}
+		if exists {
+			crates = append(crates, entry.Name())
+		}
+	}
+	sort.Strings(crates)
+	return crates, nil
+}
+
+// addWorkspaceMember returns the manifest with the member added to [workspace].members, keeping the existing members
+// in order. The manifest is unchanged if a member or glob already matches it.
+func addWorkspaceMember(manifest, member string) (string, bool) {
+	header := strings.Index(manifest, "[workspace]")
+	if header == -1 {
+		section := fmt.Sprintf("[workspace]\nresolver = \"2\"\n%s", formatMembers([]string{member}))
+		if strings.TrimSpace(manifest) == "" {
+			return section, true
+		}
+		return strings.TrimRight(manifest, "\n") + "\n\n" + section, true
+	}
+
+	// The workspace table ends at the next table header
+	start := header + len("[workspace]")
+	end := len(manifest)
+	if loc := tableHeaderRegex.FindStringIndex(manifest[start:]); loc != nil {
+		end = start + loc[0]
+	}
+	workspace := manifest[start:end]
+
+	loc := membersRegex.FindStringSubmatchIndex(workspace)
+	if loc == nil {
+		members := "\n" + strings.TrimSuffix(formatMembers([]string{member}), "\n")
+		return manifest[:start] + members + manifest[start:], true
+	}
+
+	var members []string
+	for _, match := range quotedRegex.FindAllStringSubmatch(workspace[loc[2]:loc[3]], -1) {
+		if matched, _ := path.Match(match[1], member); matched {
+			return manifest, false
+		}
+		members = append(members, match[1])
+	}
+	members = append(members, member)
+	return manifest[:start+loc[0]] + strings.TrimSuffix(formatMembers(members), "\n") + manifest[start+loc[1]:], true
+}
+
+func formatMembers(members []string) string {
+	var b strings.Builder
+	b.WriteString("members = [\n")
+	for _, m := range members {
+		fmt.Fprintf(&b, "    %q,\n", m)
+	}
+	b.WriteString("]\n")
+	return b.String()
+}
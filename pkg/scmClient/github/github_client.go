@@ -56,3 +56,20 @@ func (c *Client) AddLabels(ctx context.Context, labels []string, pullNumber int)
 	}
 	return lbs, nil
 }
+
+func (c *Client) GetPullRequest(ctx context.Context, pullNumber int) (*github.PullRequest, error) {
+	pr, _, err := c.Github.PullRequests.Get(ctx, c.Owner, c.Repo, pullNumber)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (c *Client) CreateTag(ctx context.Context, tag, sha string) error {
+	log.Info().Msgf("Creating tag %s at %s for %s/%s", tag, sha, c.Owner, c.Repo)
+	_, _, err := c.Github.Git.CreateRef(ctx, c.Owner, c.Repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + tag),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	})
+	return err
+}
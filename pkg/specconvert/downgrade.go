@@ -0,0 +1,347 @@
+// Package specconvert converts OpenAPI specs between versions
+package specconvert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// TargetVersion is the OpenAPI version specs are downgraded to
+const TargetVersion = "3.0.3"
+
+// Issue is a part of the spec that couldn't be converted and was removed or changed in meaning
+type Issue struct {
+	// Path is the JSON pointer to the element, e.g. #/components/schemas/Pet/properties/name
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// annotationKeywords are the schema keywords that don't change the generated types, so they can be dropped from
+// alongside a $ref
+var annotationKeywords = map[string]bool{
+	"description": true, "summary": true, "title": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+	"example": true, "examples": true, "default": true, "$comment": true,
+}
+
+// unsupportedKeywords are the 3.1 schema keywords that have no 3.0 equivalent
+var unsupportedKeywords = []string{
+	"$defs", "$dynamicAnchor", "$dynamicRef", "contains", "dependentRequired", "dependentSchemas", "else", "if",
+	"maxContains", "minContains", "patternProperties", "prefixItems", "propertyNames", "then", "unevaluatedItems",
+	"unevaluatedProperties",
+}
+
+// IsV31 returns whether the JSON or YAML spec is OpenAPI 3.1
+func IsV31(data []byte) bool {
+	var doc struct {
+		OpenAPI string `yaml:"openapi"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	return strings.HasPrefix(doc.OpenAPI, "3.1.")
+}
+
+// DowngradeV31 converts a JSON or YAML OpenAPI 3.1 spec to a 3.0 JSON spec. Nullable type arrays become nullable,
+// const becomes a single value enum, examples become example and $ref siblings are wrapped in an allOf. The parts that
+// can't be converted are removed and returned as issues.
+func DowngradeV31(data []byte) ([]byte, []Issue, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse spec")
+	}
+	if doc == nil {
+		return nil, nil, errors.New("spec is empty")
+	}
+
+	c := &converter{}
+	c.convertDocument(doc)
+
+	converted, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal converted spec")
+	}
+	return converted, c.issues, nil
+}
+
+type converter struct {
+	issues []Issue
+}
+
+func (c *converter) report(path, format string, args ...any) {
+	c.issues = append(c.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *converter) convertDocument(doc map[string]any) {
+	doc["openapi"] = TargetVersion
+	delete(doc, "jsonSchemaDialect")
+	if _, ok := doc["paths"]; !ok {
+		doc["paths"] = map[string]any{}
+	}
+	if info, ok := doc["info"].(map[string]any); ok {
+		delete(info, "summary")
+		if license, ok := info["license"].(map[string]any); ok {
+			delete(license, "identifier")
+		}
+	}
+	if _, ok := doc["webhooks"]; ok {
+		c.report("#/webhooks", "webhooks are not supported in OpenAPI 3.0 and were removed")
+		delete(doc, "webhooks")
+	}
+	if components, ok := doc["components"].(map[string]any); ok {
+		if _, ok := components["pathItems"]; ok {
+			c.report("#/components/pathItems", "path items components are not supported in OpenAPI 3.0 and were removed")
+			delete(components, "pathItems")
+		}
+		if schemas, ok := components["schemas"].(map[string]any); ok {
+			for _, name := range sortedKeys(schemas) {
+				c.convertSchema(schemas[name], "#/components/schemas/"+escape(name))
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(doc) {
+		if key == "components" {
+			components, ok := doc[key].(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, name := range sortedKeys(components) {
+				if name != "schemas" && name != "examples" {
+					c.walk(components[name], "#/components/"+escape(name))
+				}
+			}
+			continue
+		}
+		c.walk(doc[key], "#/"+escape(key))
+	}
+}
+
+// walk finds the schemas outside of the components schemas, i.e. those of parameters, headers and media types. Examples
+// aren't walked, as their values can have keys named schema.
+func (c *converter) walk(node any, path string) {
+	switch n := node.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(n) {
+			switch key {
+			case "schema":
+				c.convertSchema(n[key], path+"/schema")
+			case "example", "examples":
+				// Example values are payloads, not part of the spec
+			default:
+				c.walk(n[key], path+"/"+escape(key))
+			}
+		}
+	case []any:
+		for i, v := range n {
+			c.walk(v, fmt.Sprintf("%s/%d", path, i))
+		}
+	}
+}
+
+func (c *converter) convertSchema(node any, path string) {
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+
+	c.convertRef(schema)
+	c.convertType(schema, path)
+	c.convertNullableComposition(schema)
+
+	if value, ok := schema["const"]; ok {
+		if _, hasEnum := schema["enum"]; !hasEnum {
+			schema["enum"] = []any{value}
+		}
+		delete(schema, "const")
+	}
+	if examples, ok := schema["examples"].([]any); ok {
+		if _, hasExample := schema["example"]; !hasExample && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+	c.convertExclusiveBound(schema, path, "exclusiveMinimum", "minimum")
+	c.convertExclusiveBound(schema, path, "exclusiveMaximum", "maximum")
+	if encoding, ok := schema["contentEncoding"]; ok {
+		if encoding == "base64" {
+			schema["format"] = "byte"
+		}
+		delete(schema, "contentEncoding")
+	}
+	if mediaType, ok := schema["contentMediaType"]; ok {
+		if mediaType == "application/octet-stream" {
+			schema["format"] = "binary"
+		}
+		delete(schema, "contentMediaType")
+	}
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := schema[keyword]; ok {
+			c.report(path, "%s is not supported in OpenAPI 3.0 and was removed", keyword)
+			delete(schema, keyword)
+		}
+	}
+
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for _, name := range sortedKeys(properties) {
+			c.convertSchema(properties[name], path+"/properties/"+escape(name))
+		}
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		c.convertSchema(schema[keyword], path+"/"+keyword)
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if schemas, ok := schema[keyword].([]any); ok {
+			for i, s := range schemas {
+				c.convertSchema(s, fmt.Sprintf("%s/%s/%d", path, keyword, i))
+			}
+		}
+	}
+}
+
+// convertExclusiveBound converts a numeric exclusive bound to the bound with the boolean exclusive flag. If the schema
+// already has the bound, only the stricter of the two is kept.
+func (c *converter) convertExclusiveBound(schema map[string]any, path, keyword, bound string) {
+	value, ok := schema[keyword]
+	if !ok {
+		return
+	}
+	if _, isBool := value.(bool); isBool {
+		return
+	}
+	if existing, ok := schema[bound]; ok {
+		exclusive, isNumber := toFloat(value)
+		inclusive, isBoundNumber := toFloat(existing)
+		if isNumber && isBoundNumber && (bound == "minimum" && inclusive > exclusive || bound == "maximum" && inclusive < exclusive) {
+			c.report(path, "%s %v is looser than %s %v, so was removed", keyword, value, bound, existing)
+			delete(schema, keyword)
+			return
+		}
+		c.report(path, "%s %v was replaced by %s %v", bound, existing, keyword, value)
+	}
+	schema[bound] = value
+	schema[keyword] = true
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// convertRef handles the keywords alongside a $ref, which 3.0 ignores. Annotations are dropped, anything else is kept by
+// moving the $ref into an allOf.
+func (c *converter) convertRef(schema map[string]any) {
+	ref, ok := schema["$ref"]
+	if !ok || len(schema) == 1 {
+		return
+	}
+
+	wrap := false
+	for key := range schema {
+		if key != "$ref" && !annotationKeywords[key] {
+			wrap = true
+		}
+	}
+	if !wrap {
+		for key := range schema {
+			if key != "$ref" {
+				delete(schema, key)
+			}
+		}
+		return
+	}
+
+	delete(schema, "$ref")
+	allOf, _ := schema["allOf"].([]any)
+	schema["allOf"] = append([]any{map[string]any{"$ref": ref}}, allOf...)
+}
+
+// convertType converts type arrays, e.g. [string, "null"] becomes type string and nullable
+func (c *converter) convertType(schema map[string]any, path string) {
+	switch types := schema["type"].(type) {
+	case string:
+		if types == "null" {
+			c.report(path, "the null type can't be represented in OpenAPI 3.0, it was converted to a nullable value of any type")
+			delete(schema, "type")
+			schema["nullable"] = true
+		}
+	case []any:
+		var nonNull []any
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+			} else {
+				nonNull = append(nonNull, t)
+			}
+		}
+		switch len(nonNull) {
+		case 0:
+			delete(schema, "type")
+		case 1:
+			schema["type"] = nonNull[0]
+		default:
+			c.report(path, "multiple types %v can't be represented in OpenAPI 3.0, the type was removed", nonNull)
+			delete(schema, "type")
+		}
+	}
+}
+
+// convertNullableComposition converts a oneOf or anyOf with a null type, e.g. oneOf [{$ref: Pet}, {type: "null"}]
+// becomes a nullable allOf [{$ref: Pet}]
+func (c *converter) convertNullableComposition(schema map[string]any) {
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		schemas, ok := schema[keyword].([]any)
+		if !ok {
+			continue
+		}
+		var nonNull []any
+		for _, s := range schemas {
+			if m, ok := s.(map[string]any); ok && m["type"] == "null" && len(m) == 1 {
+				schema["nullable"] = true
+				continue
+			}
+			nonNull = append(nonNull, s)
+		}
+		if len(nonNull) == len(schemas) {
+			continue
+		}
+		if _, hasAllOf := schema["allOf"]; len(nonNull) == 1 && !hasAllOf {
+			delete(schema, keyword)
+			schema["allOf"] = nonNull
+			continue
+		}
+		schema[keyword] = nonNull
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escape escapes a key for use in a JSON pointer
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
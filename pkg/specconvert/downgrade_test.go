@@ -0,0 +1,231 @@
+//go:build unit
+
+package specconvert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/specconvert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDowngradeV31_Schemas(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         string
+		expectedSchema string
+		expectedIssues []string
+	}{
+		{
+			name:           "Nullable type array",
+			schema:         `{"type": ["string", "null"], "format": "date-time"}`,
+			expectedSchema: `{"type": "string", "format": "date-time", "nullable": true}`,
+		},
+		{
+			name:           "Single type array",
+			schema:         `{"type": ["integer"]}`,
+			expectedSchema: `{"type": "integer"}`,
+		},
+		{
+			name:           "Multiple types",
+			schema:         `{"type": ["string", "integer"]}`,
+			expectedSchema: `{}`,
+			expectedIssues: []string{"#/components/schemas/Test: multiple types [string integer] can't be represented in OpenAPI 3.0, the type was removed"},
+		},
+		{
+			name:           "Const",
+			schema:         `{"type": "string", "const": "pet"}`,
+			expectedSchema: `{"type": "string", "enum": ["pet"]}`,
+		},
+		{
+			name:           "Examples",
+			schema:         `{"type": "string", "examples": ["cat", "dog"]}`,
+			expectedSchema: `{"type": "string", "example": "cat"}`,
+		},
+		{
+			name:           "Numeric exclusive bounds",
+			schema:         `{"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`,
+			expectedSchema: `{"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true}`,
+		},
+		{
+			name:           "Exclusive bounds stricter than the bounds",
+			schema:         `{"type": "number", "minimum": 0, "exclusiveMinimum": 5, "maximum": 10, "exclusiveMaximum": 10}`,
+			expectedSchema: `{"type": "number", "minimum": 5, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true}`,
+			expectedIssues: []string{
+				"#/components/schemas/Test: minimum 0 was replaced by exclusiveMinimum 5",
+				"#/components/schemas/Test: maximum 10 was replaced by exclusiveMaximum 10",
+			},
+		},
+		{
+			name:           "Bounds stricter than the exclusive bounds",
+			schema:         `{"type": "number", "minimum": 1.5, "exclusiveMinimum": 1, "maximum": 8, "exclusiveMaximum": 9.5}`,
+			expectedSchema: `{"type": "number", "minimum": 1.5, "maximum": 8}`,
+			expectedIssues: []string{
+				"#/components/schemas/Test: exclusiveMinimum 1 is looser than minimum 1.5, so was removed",
+				"#/components/schemas/Test: exclusiveMaximum 9.5 is looser than maximum 8, so was removed",
+			},
+		},
+		{
+			name:           "Binary content",
+			schema:         `{"type": "string", "contentMediaType": "application/octet-stream"}`,
+			expectedSchema: `{"type": "string", "format": "binary"}`,
+		},
+		{
+			name:           "Ref with annotations",
+			schema:         `{"$ref": "#/components/schemas/Pet", "description": "The pet"}`,
+			expectedSchema: `{"$ref": "#/components/schemas/Pet"}`,
+		},
+		{
+			name:           "Ref with other keywords",
+			schema:         `{"$ref": "#/components/schemas/Pet", "description": "The pet", "readOnly": true, "maxProperties": 2}`,
+			expectedSchema: `{"allOf": [{"$ref": "#/components/schemas/Pet"}], "description": "The pet", "readOnly": true, "maxProperties": 2}`,
+		},
+		{
+			name:           "Nullable ref",
+			schema:         `{"oneOf": [{"$ref": "#/components/schemas/Pet"}, {"type": "null"}]}`,
+			expectedSchema: `{"allOf": [{"$ref": "#/components/schemas/Pet"}], "nullable": true}`,
+		},
+		{
+			name: "Nested schemas",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"tags": {"type": "array", "items": {"type": ["string", "null"]}},
+					"labels": {"type": "object", "additionalProperties": {"const": 1}}
+				}
+			}`,
+			expectedSchema: `{
+				"type": "object",
+				"properties": {
+					"tags": {"type": "array", "items": {"type": "string", "nullable": true}},
+					"labels": {"type": "object", "additionalProperties": {"enum": [1]}}
+				}
+			}`,
+		},
+		{
+			name:           "Unsupported keywords",
+			schema:         `{"type": "array", "prefixItems": [{"type": "string"}], "if": {"type": "string"}}`,
+			expectedSchema: `{"type": "array"}`,
+			expectedIssues: []string{
+				"#/components/schemas/Test: if is not supported in OpenAPI 3.0 and was removed",
+				"#/components/schemas/Test: prefixItems is not supported in OpenAPI 3.0 and was removed",
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := `{"openapi": "3.1.0", "info": {"title": "Test", "version": "1.0.0"}, "paths": {}, "components": {"schemas": {"Test": ` + tt.schema + `}}}`
+
+			converted, issues, err := specconvert.DowngradeV31([]byte(spec))
+			require.NoError(t, err)
+
+			var doc struct {
+				OpenAPI    string `json:"openapi"`
+				Components struct {
+					Schemas map[string]json.RawMessage `json:"schemas"`
+				} `json:"components"`
+			}
+			require.NoError(t, json.Unmarshal(converted, &doc))
+			assert.Equal(t, specconvert.TargetVersion, doc.OpenAPI)
+			assert.JSONEq(t, tt.expectedSchema, string(doc.Components.Schemas["Test"]))
+
+			var actualIssues []string
+			for _, issue := range issues {
+				actualIssues = append(actualIssues, issue.String())
+			}
+			assert.Equal(t, tt.expectedIssues, actualIssues)
+		})
+	}
+}
+
+func TestDowngradeV31_Document(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+info:
+  title: Test
+  summary: A test API
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: OK
+components:
+  examples:
+    schemaPayload:
+      value:
+        schema:
+          const: a
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: [integer, "null"]
+`
+	converted, issues, err := specconvert.DowngradeV31([]byte(spec))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0", "license": {"name": "MIT"}},
+		"paths": {},
+		"components": {
+			"examples": {
+				"schemaPayload": {"value": {"schema": {"const": "a"}}}
+			},
+			"parameters": {
+				"limit": {"name": "limit", "in": "query", "schema": {"type": "integer", "nullable": true}}
+			}
+		}
+	}`, string(converted))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "#/webhooks", issues[0].Path)
+}
+
+func TestDowngradeV31_PathSchemas(t *testing.T) {
+	spec := `{
+		"openapi": "3.1.0",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"paths": {
+			"/pets/{id}": {
+				"get": {
+					"responses": {
+						"200": {
+							"description": "OK",
+							"content": {"application/json": {
+								"schema": {"type": "object", "properties": {"id": {"type": "string", "patternProperties": {}}}},
+								"example": {"schema": {"const": "a"}},
+								"examples": {"payload": {"value": {"schema": {"const": "a"}}}}
+							}}
+						}
+					}
+				}
+			}
+		}
+	}`
+	converted, issues, err := specconvert.DowngradeV31([]byte(spec))
+	require.NoError(t, err)
+
+	// Example payloads with a schema key are left as they are
+	assert.Equal(t, 2, strings.Count(string(converted), `"const": "a"`))
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, "#/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/id", issues[0].Path)
+}
+
+func TestIsV31(t *testing.T) {
+	assert.True(t, specconvert.IsV31([]byte(`{"openapi": "3.1.0"}`)))
+	assert.True(t, specconvert.IsV31([]byte("openapi: 3.1.1\n")))
+	assert.False(t, specconvert.IsV31([]byte(`{"openapi": "3.0.3"}`)))
+	assert.False(t, specconvert.IsV31([]byte(`{"swagger": "2.0"}`)))
+}
@@ -1,64 +1,20 @@
 package uv
 
 import (
-	"fmt"
-	"path/filepath"
-	"regexp"
-	"strings"
-
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/commandrunner"
 	"github.com/spring-financial-group/jx3-openapi-generation/pkg/domain"
-	"github.com/spring-financial-group/jx3-openapi-generation/pkg/file"
 )
 
 type UVClient struct {
-	cmd    domain.CommandRunner
-	FileIO domain.FileIO
+	cmd domain.CommandRunner
 }
 
 func NewClient() domain.UVClient {
 	return &UVClient{
-		cmd:    commandrunner.NewCommandRunner(),
-		FileIO: file.NewFileIO(),
-	}
-}
-
-func (c *UVClient) GeneratePyProjectFile(dir, packageName, packageVersion string) error {
-	pkgVersion := c.extractVersion(packageVersion)
-
-	pyProjectContent := fmt.Sprintf(`[project]
-name = "%s"
-version = "%s"
-description = "%s schema package generated from OpenAPI specification"
-readme = "%s_README.md"
-classifiers = [
-	"Programming Language :: Python :: 3.10",
-	"Private :: pyx :: mqube"
-]
-requires-python = ">=3.10.0, <3.11"
-
-[build-system]
-requires = ["uv_build>=0.9.3,<0.10.0"]
-build-backend = "uv_build"
-
-[[tool.uv.index]]
-name = "pyx"
-url = "https://api.pyx.dev/simple/mqube/main"
-publish-url = "https://api.pyx.dev/v1/upload/mqube/main"
-
-[tool.uv.build-backend]
-module-name = "%s"
-module-root = ""
-`, packageName, pkgVersion, packageName, packageName, packageName)
-
-	pyProjectPath := filepath.Join(dir, "pyproject.toml")
-	err := c.FileIO.Write(pyProjectPath, []byte(pyProjectContent), 0644)
-	if err != nil {
-		return errors.Wrap(err, "failed to write pyproject.toml")
+		cmd: commandrunner.NewCommandRunner(),
 	}
-	return nil
 }
 
 func (c *UVClient) BuildProject(dir string) error {
@@ -77,6 +33,18 @@ func (c *UVClient) PublishProject(dir string, indexName string) error {
 	return nil
 }
 
+func (c *UVClient) RunIsolated(dir string, requirements []string, command ...string) error {
+	args := []string{"run", "--isolated", "--no-project"}
+	for _, requirement := range requirements {
+		args = append(args, "--with", requirement)
+	}
+	err := c.uvCommand(dir, append(args, command...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run %s", command[0])
+	}
+	return nil
+}
+
 func (c *UVClient) uvCommand(dir string, args ...string) error {
 	log.Info().Msgf("Running uv command: uv %s", args)
 	out, err := c.cmd.Execute(dir, "uv", args...)
@@ -86,33 +54,3 @@ func (c *UVClient) uvCommand(dir string, args ...string) error {
 	}
 	return nil
 }
-
-// Because:
-// https://packaging.python.org/en/latest/specifications/version-specifiers/#public-version-identifiers
-func (c *UVClient) extractVersion(pkgVersion string) string {
-	// Example: 0.0.0-PR-123-12-SNAPSHOT
-	if strings.Contains(pkgVersion, "SNAPSHOT") {
-		pattern := `-([0-9][0-9]?[0-9]?)` // Matches any dash followed by up to 3 digits
-		regMatch := regexp.MustCompile(pattern)
-		matches := regMatch.FindAllStringSubmatch(pkgVersion, -1)
-
-		var suffix string
-		if len(matches) >= 2 {
-			suffix = fmt.Sprintf(".preview%s.dev%s", matches[0][1], matches[1][1])
-		} else {
-			suffix = ".dev"
-		}
-
-		// This part replaces everything after the first dash with the suffix above
-		versionPattern := `\.[0-9][0-9]?[0-9]?(-)` // Matches a dot followed by up to 3 digits and a dash
-		versionRegexp := regexp.MustCompile(versionPattern)
-		versionIndex := versionRegexp.FindStringIndex(pkgVersion)
-		if len(versionIndex) >= 2 {
-			pkgVersion = pkgVersion[:versionIndex[0]] + suffix
-		} else {
-			pkgVersion += suffix
-		}
-	}
-
-	return pkgVersion
-}
@@ -0,0 +1,176 @@
+// Package version translates service versions into the version formats of each package ecosystem
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotSuffix = "SNAPSHOT"
+
+	// NPMLatestTag is the npm dist-tag for releases
+	NPMLatestTag = "latest"
+	// NPMPreviewTag is the npm dist-tag for prereleases, so that they aren't installed by default
+	NPMPreviewTag = "preview"
+)
+
+var (
+	// previewRegex matches the prerelease of JX preview versions, e.g. PR-123-4-SNAPSHOT
+	previewRegex = regexp.MustCompile(`^PR-(\d+)-(\d+)-SNAPSHOT$`)
+	// preReleaseRegex matches the prereleases with a PEP 440 equivalent, e.g. alpha.1, beta2 or rc.3
+	preReleaseRegex = regexp.MustCompile(`^(alpha|a|beta|b|rc|c|pre|preview)[.-]?(\d*)$`)
+	trailingNumber  = regexp.MustCompile(`(\d+)$`)
+)
+
+// pep440PreReleases maps prerelease names to their normalised PEP 440 pre-release segment
+var pep440PreReleases = map[string]string{
+	"alpha": "a", "a": "a", "beta": "b", "b": "b", "rc": "rc", "c": "rc", "pre": "rc", "preview": "rc",
+}
+
+// Version is a service version, which is semver including JX preview versions such as 0.0.0-PR-123-4-SNAPSHOT
+type Version struct {
+	semver *semver.Version
+	// PullRequest is the pull request number of a JX preview version, otherwise zero
+	PullRequest int
+	// Build is the build number of a JX preview version, otherwise zero
+	Build int
+}
+
+// Parse parses a service version, e.g. 1.2.3, v1.2.3 or 0.0.0-PR-123-4-SNAPSHOT. Like the Go module versions were
+// before, it is lenient, so short versions such as 1.2 are parsed as 1.2.0.
+func Parse(version string) (*Version, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version %q", version)
+	}
+
+	parsed := &Version{semver: v}
+	if match := previewRegex.FindStringSubmatch(v.Prerelease()); match != nil {
+		parsed.PullRequest, _ = strconv.Atoi(match[1])
+		parsed.Build, _ = strconv.Atoi(match[2])
+	}
+	return parsed, nil
+}
+
+// String returns the version as semver, without a v prefix
+func (v *Version) String() string {
+	return v.semver.String()
+}
+
+// Major returns the major version
+func (v *Version) Major() uint64 {
+	return v.semver.Major()
+}
+
+// IsPrerelease returns whether the version has a prerelease, e.g. 1.0.0-alpha.1 or 0.0.0-PR-123-4-SNAPSHOT
+func (v *Version) IsPrerelease() bool {
+	return v.semver.Prerelease() != ""
+}
+
+// IsSnapshot returns whether the version is a snapshot, which includes JX preview versions
+func (v *Version) IsSnapshot() bool {
+	return strings.HasSuffix(strings.ToUpper(v.semver.Prerelease()), snapshotSuffix)
+}
+
+// IsPreview returns whether the version is a JX preview version built from a pull request
+func (v *Version) IsPreview() bool {
+	return v.PullRequest != 0
+}
+
+// WithMajor returns the version with its major version replaced, e.g. to release a v0 preview of another major version
+func (v *Version) WithMajor(major uint64) *Version {
+	return &Version{
+		semver:      semver.New(major, v.semver.Minor(), v.semver.Patch(), v.semver.Prerelease(), v.semver.Metadata()),
+		PullRequest: v.PullRequest,
+		Build:       v.Build,
+	}
+}
+
+// core returns major.minor.patch
+func (v *Version) core() string {
+	return fmt.Sprintf("%d.%d.%d", v.semver.Major(), v.semver.Minor(), v.semver.Patch())
+}
+
+// withoutMetadata returns the semver version without build metadata, which most ecosystems ignore or reject
+func (v *Version) withoutMetadata() string {
+	if !v.IsPrerelease() {
+		return v.core()
+	}
+	return v.core() + "-" + v.semver.Prerelease()
+}
+
+// PEP440 returns the normalised PEP 440 version for Python. JX previews are release candidates with the pull request
+// number and a dev release with the build number, e.g. 0.0.0rc123.dev4, so that later builds sort after earlier ones.
+// Other snapshots and prereleases without a PEP 440 equivalent are dev releases.
+func (v *Version) PEP440() string {
+	prerelease := v.semver.Prerelease()
+	switch {
+	case prerelease == "":
+		return v.core()
+	case v.IsPreview():
+		return fmt.Sprintf("%src%d.dev%d", v.core(), v.PullRequest, v.Build)
+	}
+
+	if match := preReleaseRegex.FindStringSubmatch(strings.ToLower(prerelease)); match != nil {
+		number := match[2]
+		if number == "" {
+			number = "0"
+		}
+		n, _ := strconv.Atoi(number)
+		return fmt.Sprintf("%s%s%d", v.core(), pep440PreReleases[match[1]], n)
+	}
+
+	devNumber := 0
+	if match := trailingNumber.FindStringSubmatch(strings.TrimSuffix(strings.ToUpper(prerelease), "-"+snapshotSuffix)); match != nil {
+		devNumber, _ = strconv.Atoi(match[1])
+	}
+	return fmt.Sprintf("%s.dev%d", v.core(), devNumber)
+}
+
+// NPM returns the npm version, which is semver without build metadata
+func (v *Version) NPM() string {
+	return v.withoutMetadata()
+}
+
+// NPMDistTag returns the dist-tag the npm package is published with, prereleases have their own so that they aren't
+// installed by default
+func (v *Version) NPMDistTag() string {
+	if v.IsPrerelease() {
+		return NPMPreviewTag
+	}
+	return NPMLatestTag
+}
+
+// NuGet returns the NuGet version, which is SemVer 2.0 without build metadata
+func (v *Version) NuGet() string {
+	return v.withoutMetadata()
+}
+
+// Maven returns the Maven version. Snapshots end in -SNAPSHOT, which Maven repositories treat as mutable.
+func (v *Version) Maven() string {
+	if !v.IsSnapshot() {
+		return v.withoutMetadata()
+	}
+	prerelease := v.semver.Prerelease()
+	prerelease = strings.TrimSuffix(prerelease[:len(prerelease)-len(snapshotSuffix)], "-")
+	if prerelease == "" {
+		return v.core() + "-" + snapshotSuffix
+	}
+	return v.core() + "-" + prerelease + "-" + snapshotSuffix
+}
+
+// Cargo returns the Cargo version, which is semver
+func (v *Version) Cargo() string {
+	return v.String()
+}
+
+// GoModule returns the Go module version, which is semver with a v prefix and no build metadata
+func (v *Version) GoModule() string {
+	return "v" + v.withoutMetadata()
+}
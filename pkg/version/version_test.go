@@ -0,0 +1,181 @@
+//go:build unit
+
+package version_test
+
+import (
+	"testing"
+
+	"github.com/spring-financial-group/jx3-openapi-generation/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		version             string
+		expectedString      string
+		expectedPrerelease  bool
+		expectedSnapshot    bool
+		expectedPullRequest int
+		expectedBuild       int
+		expectedErr         string
+	}{
+		{version: "1.2.3", expectedString: "1.2.3"},
+		{version: "v1.2.3", expectedString: "1.2.3"},
+		{version: "1.0.0-alpha.1", expectedString: "1.0.0-alpha.1", expectedPrerelease: true},
+		{version: "0.0.0-PR-123-4-SNAPSHOT", expectedString: "0.0.0-PR-123-4-SNAPSHOT", expectedPrerelease: true, expectedSnapshot: true, expectedPullRequest: 123, expectedBuild: 4},
+		{version: "1.2.3-SNAPSHOT", expectedString: "1.2.3-SNAPSHOT", expectedPrerelease: true, expectedSnapshot: true},
+		{version: "1.2", expectedString: "1.2.0"},
+		{version: "v2", expectedString: "2.0.0"},
+		{version: "latest", expectedErr: `invalid version "latest"`},
+		{version: "1.2.3.4", expectedErr: `invalid version "1.2.3.4"`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := version.Parse(tt.version)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedString, v.String())
+			assert.Equal(t, tt.expectedPrerelease, v.IsPrerelease())
+			assert.Equal(t, tt.expectedSnapshot, v.IsSnapshot())
+			assert.Equal(t, tt.expectedPullRequest != 0, v.IsPreview())
+			assert.Equal(t, tt.expectedPullRequest, v.PullRequest)
+			assert.Equal(t, tt.expectedBuild, v.Build)
+		})
+	}
+}
+
+func TestVersion_Render(t *testing.T) {
+	testCases := []struct {
+		version        string
+		expectedPEP440 string
+		expectedNPM    string
+		expectedNPMTag string
+		expectedNuGet  string
+		expectedMaven  string
+		expectedCargo  string
+		expectedGo     string
+	}{
+		{
+			version:        "1.2.3",
+			expectedPEP440: "1.2.3",
+			expectedNPM:    "1.2.3",
+			expectedNPMTag: version.NPMLatestTag,
+			expectedNuGet:  "1.2.3",
+			expectedMaven:  "1.2.3",
+			expectedCargo:  "1.2.3",
+			expectedGo:     "v1.2.3",
+		},
+		{
+			version:        "v2.0.1",
+			expectedPEP440: "2.0.1",
+			expectedNPM:    "2.0.1",
+			expectedNPMTag: version.NPMLatestTag,
+			expectedNuGet:  "2.0.1",
+			expectedMaven:  "2.0.1",
+			expectedCargo:  "2.0.1",
+			expectedGo:     "v2.0.1",
+		},
+		{
+			version:        "0.0.0-PR-123-4-SNAPSHOT",
+			expectedPEP440: "0.0.0rc123.dev4",
+			expectedNPM:    "0.0.0-PR-123-4-SNAPSHOT",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "0.0.0-PR-123-4-SNAPSHOT",
+			expectedMaven:  "0.0.0-PR-123-4-SNAPSHOT",
+			expectedCargo:  "0.0.0-PR-123-4-SNAPSHOT",
+			expectedGo:     "v0.0.0-PR-123-4-SNAPSHOT",
+		},
+		{
+			version:        "1.4.0-SNAPSHOT",
+			expectedPEP440: "1.4.0.dev0",
+			expectedNPM:    "1.4.0-SNAPSHOT",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "1.4.0-SNAPSHOT",
+			expectedMaven:  "1.4.0-SNAPSHOT",
+			expectedCargo:  "1.4.0-SNAPSHOT",
+			expectedGo:     "v1.4.0-SNAPSHOT",
+		},
+		{
+			version:        "1.4.0-build.7-snapshot",
+			expectedPEP440: "1.4.0.dev7",
+			expectedNPM:    "1.4.0-build.7-snapshot",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "1.4.0-build.7-snapshot",
+			expectedMaven:  "1.4.0-build.7-SNAPSHOT",
+			expectedCargo:  "1.4.0-build.7-snapshot",
+			expectedGo:     "v1.4.0-build.7-snapshot",
+		},
+		{
+			version:        "1.0.0-alpha.1",
+			expectedPEP440: "1.0.0a1",
+			expectedNPM:    "1.0.0-alpha.1",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "1.0.0-alpha.1",
+			expectedMaven:  "1.0.0-alpha.1",
+			expectedCargo:  "1.0.0-alpha.1",
+			expectedGo:     "v1.0.0-alpha.1",
+		},
+		{
+			version:        "1.0.0-beta2",
+			expectedPEP440: "1.0.0b2",
+			expectedNPM:    "1.0.0-beta2",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "1.0.0-beta2",
+			expectedMaven:  "1.0.0-beta2",
+			expectedCargo:  "1.0.0-beta2",
+			expectedGo:     "v1.0.0-beta2",
+		},
+		{
+			version:        "1.0.0-rc",
+			expectedPEP440: "1.0.0rc0",
+			expectedNPM:    "1.0.0-rc",
+			expectedNPMTag: version.NPMPreviewTag,
+			expectedNuGet:  "1.0.0-rc",
+			expectedMaven:  "1.0.0-rc",
+			expectedCargo:  "1.0.0-rc",
+			expectedGo:     "v1.0.0-rc",
+		},
+		{
+			version:        "1.2.3+build.5",
+			expectedPEP440: "1.2.3",
+			expectedNPM:    "1.2.3",
+			expectedNPMTag: version.NPMLatestTag,
+			expectedNuGet:  "1.2.3",
+			expectedMaven:  "1.2.3",
+			expectedCargo:  "1.2.3+build.5",
+			expectedGo:     "v1.2.3",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := version.Parse(tt.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedPEP440, v.PEP440(), "PEP 440")
+			assert.Equal(t, tt.expectedNPM, v.NPM(), "npm")
+			assert.Equal(t, tt.expectedNPMTag, v.NPMDistTag(), "npm dist-tag")
+			assert.Equal(t, tt.expectedNuGet, v.NuGet(), "NuGet")
+			assert.Equal(t, tt.expectedMaven, v.Maven(), "Maven")
+			assert.Equal(t, tt.expectedCargo, v.Cargo(), "Cargo")
+			assert.Equal(t, tt.expectedGo, v.GoModule(), "Go")
+		})
+	}
+}
+
+func TestVersion_WithMajor(t *testing.T) {
+	v, err := version.Parse("0.0.0-PR-123-4-SNAPSHOT+build.1")
+	require.NoError(t, err)
+
+	withMajor := v.WithMajor(2)
+	assert.Equal(t, "2.0.0-PR-123-4-SNAPSHOT+build.1", withMajor.String())
+	assert.Equal(t, "v2.0.0-PR-123-4-SNAPSHOT", withMajor.GoModule())
+	assert.Equal(t, uint64(2), withMajor.Major())
+	assert.True(t, withMajor.IsPreview())
+	assert.Equal(t, "0.0.0-PR-123-4-SNAPSHOT+build.1", v.String())
+}